@@ -0,0 +1,209 @@
+package tekton
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// getApplyPatch encodes a typed Tekton object as a server-side apply patch. Unset fields and
+// the status are left out, so that the provider only takes ownership of configured fields.
+func getApplyPatch(object runtime.Object) ([]byte, error) {
+	gvks, _, err := tektonScheme.ObjectKinds(object)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return nil, err
+	}
+	removeNulls(content)
+	delete(content, "status")
+	content["apiVersion"], content["kind"] = gvks[0].ToAPIVersionAndKind()
+
+	return json.Marshal(content)
+}
+
+func removeNulls(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, item := range v {
+			if item == nil {
+				delete(v, k)
+				continue
+			}
+			removeNulls(item)
+		}
+	case []interface{}:
+		for _, item := range v {
+			removeNulls(item)
+		}
+	}
+}
+
+// getApplyOptions returns the options of a server-side apply patch by the provider's field manager.
+func getApplyOptions(clients providerClients) metav1.PatchOptions {
+	force := clients.ForceConflicts
+	return metav1.PatchOptions{
+		FieldManager: clients.FieldManager,
+		Force:        &force,
+	}
+}
+
+// getManagedFields returns the fields the provider's field manager owns through server-side
+// apply, in the FieldsV1 format. It returns false if the manager has not applied the object,
+// e.g. for objects created by older provider versions.
+func getManagedFields(clients providerClients, meta metav1.ObjectMeta) (map[string]interface{}, bool) {
+	for _, entry := range meta.ManagedFields {
+		if entry.Manager != clients.FieldManager || entry.Operation != metav1.ManagedFieldsOperationApply || entry.FieldsV1 == nil {
+			continue
+		}
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err == nil {
+			return fields, true
+		}
+	}
+	return nil, false
+}
+
+// ownsField reports whether the managed fields include the field at the given path.
+func ownsField(fields map[string]interface{}, path ...string) bool {
+	for _, name := range path {
+		next, ok := fields["f:"+name].(map[string]interface{})
+		if !ok {
+			return false
+		}
+		fields = next
+	}
+	return true
+}
+
+// getOwnedContent returns the parts of an object's content covered by the managed fields.
+// A field without nested entries is owned whole.
+func getOwnedContent(fields map[string]interface{}, content interface{}) interface{} {
+	if len(fields) == 0 {
+		return content
+	}
+
+	switch c := content.(type) {
+	case map[string]interface{}:
+		owned := map[string]interface{}{}
+		for key, nested := range fields {
+			if !strings.HasPrefix(key, "f:") {
+				continue
+			}
+			name := strings.TrimPrefix(key, "f:")
+			if value, ok := c[name]; ok {
+				nestedFields, _ := nested.(map[string]interface{})
+				owned[name] = getOwnedContent(nestedFields, value)
+			}
+		}
+		return owned
+	case []interface{}:
+		var owned []interface{}
+		for i, item := range c {
+			for key, nested := range fields {
+				if listItemMatches(key, i, item) {
+					nestedFields, _ := nested.(map[string]interface{})
+					owned = append(owned, getOwnedContent(nestedFields, item))
+					break
+				}
+			}
+		}
+		return owned
+	default:
+		return content
+	}
+}
+
+// listItemMatches reports whether a FieldsV1 list entry, of the form k:<keys>, v:<value> or
+// i:<index>, refers to the given list item.
+func listItemMatches(key string, index int, item interface{}) bool {
+	switch {
+	case strings.HasPrefix(key, "k:"):
+		var keys map[string]interface{}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "k:")), &keys); err != nil {
+			return false
+		}
+		itemData, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range keys {
+			if !jsonEqual(itemData[k], v) {
+				return false
+			}
+		}
+		return true
+	case strings.HasPrefix(key, "v:"):
+		var value interface{}
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(key, "v:")), &value); err != nil {
+			return false
+		}
+		return jsonEqual(item, value)
+	case strings.HasPrefix(key, "i:"):
+		i, err := strconv.Atoi(strings.TrimPrefix(key, "i:"))
+		return err == nil && i == index
+	default:
+		return false
+	}
+}
+
+func jsonEqual(a, b interface{}) bool {
+	aJSON, errA := json.Marshal(a)
+	bJSON, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aJSON) == string(bJSON)
+}
+
+// managesField reports whether the provider's field manager owns the field at the given path.
+// Objects the manager has not applied are treated as wholly managed.
+func managesField(clients providerClients, meta metav1.ObjectMeta, path ...string) bool {
+	fields, applied := getManagedFields(clients, meta)
+	return !applied || ownsField(fields, path...)
+}
+
+// setManagedField sets an attribute to value if the provider's field manager owns the field at
+// path, and clears it otherwise, so that a field taken over by another manager shows as a change.
+func setManagedField(clients providerClients, d *schema.ResourceData, meta metav1.ObjectMeta, key string, value interface{}, path ...string) {
+	if managesField(clients, meta, path...) {
+		d.Set(key, value)
+	} else {
+		d.Set(key, nil)
+	}
+}
+
+// existingObjectError explains why an object cannot be created, given the error of looking it up
+// first: it already exists, or the lookup failed. Server-side apply would otherwise silently
+// take over an existing object, which a create must not do.
+func existingObjectError(err error, kind, namespace, name string) error {
+	if namespace != "" {
+		name = namespace + "/" + name
+	}
+	if err == nil {
+		return fmt.Errorf("failed to create Tekton %s: %s already exists", kind, name)
+	}
+	return fmt.Errorf("failed to create Tekton %s: failed to check whether %s exists: %v", kind, name, err)
+}
+
+// getPriorString returns the string at path in a block of the prior state, where each step of the
+// path but the last names a nested block, or "" if there is none.
+func getPriorString(tfBlock []interface{}, path ...string) string {
+	for i, key := range path {
+		if len(tfBlock) == 0 || tfBlock[0] == nil {
+			return ""
+		}
+		data := tfBlock[0].(map[string]interface{})
+		if i == len(path)-1 {
+			value, _ := data[key].(string)
+			return value
+		}
+		tfBlock, _ = data[key].([]interface{})
+	}
+	return ""
+}
@@ -0,0 +1,283 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersfake "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestOwnsField(t *testing.T) {
+	fields := map[string]interface{}{
+		"f:metadata": map[string]interface{}{
+			"f:labels": map[string]interface{}{
+				"f:app": map[string]interface{}{},
+			},
+		},
+		"f:spec": map[string]interface{}{
+			"f:params": map[string]interface{}{},
+		},
+	}
+
+	tests := []struct {
+		name string
+		path []string
+		want bool
+	}{
+		{name: "owned leaf", path: []string{"metadata", "labels", "app"}, want: true},
+		{name: "owned parent", path: []string{"metadata", "labels"}, want: true},
+		{name: "owned whole field", path: []string{"spec", "params"}, want: true},
+		{name: "not owned key", path: []string{"metadata", "labels", "team"}, want: false},
+		{name: "below a field owned whole", path: []string{"spec", "params", "name"}, want: false},
+		{name: "not owned top level", path: []string{"status"}, want: false},
+		{name: "empty path", path: nil, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ownsField(fields, tt.path...); got != tt.want {
+				t.Errorf("ownsField(%v) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetOwnedContent(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  string
+		content string
+		want    string
+	}{
+		{
+			name:    "no fields owns everything",
+			fields:  `{}`,
+			content: `{"a":1,"b":{"c":2}}`,
+			want:    `{"a":1,"b":{"c":2}}`,
+		},
+		{
+			name:    "map keys",
+			fields:  `{"f:spec":{"f:serviceAccountName":{}}}`,
+			content: `{"spec":{"serviceAccountName":"sa","other":"x"},"status":{}}`,
+			want:    `{"spec":{"serviceAccountName":"sa"}}`,
+		},
+		{
+			name:    "owned field missing from content",
+			fields:  `{"f:spec":{"f:missing":{}}}`,
+			content: `{"spec":{"other":"x"}}`,
+			want:    `{"spec":{}}`,
+		},
+		{
+			name:    "list items by key",
+			fields:  `{"f:params":{"k:{\"name\":\"a\"}":{".":{},"f:name":{},"f:value":{}}}}`,
+			content: `{"params":[{"name":"a","value":"1"},{"name":"b","value":"2"}]}`,
+			want:    `{"params":[{"name":"a","value":"1"}]}`,
+		},
+		{
+			name:    "list items by value",
+			fields:  `{"f:finalizers":{"v:\"mine\"":{}}}`,
+			content: `{"finalizers":["theirs","mine"]}`,
+			want:    `{"finalizers":["mine"]}`,
+		},
+		{
+			name:    "list items by index",
+			fields:  `{"f:args":{"i:1":{}}}`,
+			content: `{"args":["a","b","c"]}`,
+			want:    `{"args":["b"]}`,
+		},
+		{
+			name:    "nested fields of list items",
+			fields:  `{"f:steps":{"k:{\"name\":\"build\"}":{"f:name":{},"f:image":{}}}}`,
+			content: `{"steps":[{"name":"build","image":"golang","resources":{}}]}`,
+			want:    `{"steps":[{"name":"build","image":"golang"}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fields map[string]interface{}
+			var content, want interface{}
+			mustUnmarshal(t, tt.fields, &fields)
+			mustUnmarshal(t, tt.content, &content)
+			mustUnmarshal(t, tt.want, &want)
+
+			if got := getOwnedContent(fields, content); !reflect.DeepEqual(got, want) {
+				t.Errorf("getOwnedContent() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestManagesField(t *testing.T) {
+	clients := providerClients{FieldManager: "terraform"}
+	applied := metav1.ManagedFieldsEntry{
+		Manager:   "terraform",
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:mode":{}}}`)},
+	}
+	updated := metav1.ManagedFieldsEntry{
+		Manager:   "terraform",
+		Operation: metav1.ManagedFieldsOperationUpdate,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:resources":{}}}`)},
+	}
+
+	tests := []struct {
+		name    string
+		entries []metav1.ManagedFieldsEntry
+		path    []string
+		want    bool
+	}{
+		{name: "applied and owned", entries: []metav1.ManagedFieldsEntry{applied}, path: []string{"spec", "mode"}, want: true},
+		{name: "applied and not owned", entries: []metav1.ManagedFieldsEntry{applied}, path: []string{"spec", "resources"}, want: false},
+		{name: "never applied", entries: nil, path: []string{"spec", "resources"}, want: true},
+		{name: "only updated", entries: []metav1.ManagedFieldsEntry{updated}, path: []string{"spec", "mode"}, want: true},
+		{name: "other manager", entries: []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationApply, FieldsV1: applied.FieldsV1}}, path: []string{"spec", "resources"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := metav1.ObjectMeta{ManagedFields: tt.entries}
+			if got := managesField(clients, meta, tt.path...); got != tt.want {
+				t.Errorf("managesField(%v) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetApplyPatch(t *testing.T) {
+	task := &tektonv1beta1.Task{
+		ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "ci"},
+		Spec: tektonv1beta1.TaskSpec{
+			Steps: []tektonv1beta1.Step{{Name: "build", Image: "golang"}},
+		},
+	}
+
+	patch, err := getApplyPatch(task)
+	if err != nil {
+		t.Fatalf("getApplyPatch() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	mustUnmarshal(t, string(patch), &got)
+	if got["apiVersion"] != "tekton.dev/v1beta1" || got["kind"] != "Task" {
+		t.Errorf("getApplyPatch() apiVersion and kind = %v %v, want tekton.dev/v1beta1 Task", got["apiVersion"], got["kind"])
+	}
+	if _, ok := got["status"]; ok {
+		t.Errorf("getApplyPatch() includes status: %s", patch)
+	}
+	if _, ok := got["metadata"].(map[string]interface{})["creationTimestamp"]; ok {
+		t.Errorf("getApplyPatch() includes a null creationTimestamp: %s", patch)
+	}
+}
+
+func TestResourceTektonTriggerBindingCreateExisting(t *testing.T) {
+	client := triggersfake.NewSimpleClientset(&tektonv1alpha1.TriggerBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "push", Namespace: "ci"},
+	})
+	d := schema.TestResourceDataRaw(t, resourceTektonTriggerBinding().Schema, map[string]interface{}{
+		"name":      "push",
+		"namespace": "ci",
+		"bindings":  []interface{}{map[string]interface{}{"name": "revision", "value": "$(body.head_commit.id)"}},
+	})
+
+	err := resourceTektonTriggerBindingCreate(d, providerClients{TektonTriggersClient: client, FieldManager: "terraform"})
+	if want := "failed to create Tekton TriggerBinding: ci/push already exists"; err == nil || err.Error() != want {
+		t.Errorf("resourceTektonTriggerBindingCreate() error = %v, want %q", err, want)
+	}
+	if d.Id() != "" {
+		t.Errorf("id = %q, want none", d.Id())
+	}
+}
+
+func TestExistingObjectError(t *testing.T) {
+	forbidden := errors.NewForbidden(k8sschema.GroupResource{Group: "tekton.dev", Resource: "pipelines"}, "build", fmt.Errorf("denied"))
+
+	tests := []struct {
+		name      string
+		err       error
+		namespace string
+		want      string
+	}{
+		{name: "exists", namespace: "ci", want: "failed to create Tekton Pipeline: ci/build already exists"},
+		{name: "cluster-scoped exists", want: "failed to create Tekton Pipeline: build already exists"},
+		{name: "lookup failed", err: forbidden, namespace: "ci", want: "failed to create Tekton Pipeline: failed to check whether ci/build exists: " + forbidden.Error()},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := existingObjectError(tt.err, "Pipeline", tt.namespace, "build"); got.Error() != tt.want {
+				t.Errorf("existingObjectError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetManagedField(t *testing.T) {
+	meta := metav1.ObjectMeta{ManagedFields: []metav1.ManagedFieldsEntry{{
+		Manager:   "terraform",
+		Operation: metav1.ManagedFieldsOperationApply,
+		FieldsV1:  &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:serviceAccountName":{}}}`)},
+	}}}
+	clients := providerClients{FieldManager: "terraform"}
+	d := testResourceData(t, resourceTektonTrigger(), map[string]interface{}{
+		"name":                 "push",
+		"service_account_name": "old",
+		"bindings":             []interface{}{map[string]interface{}{"ref": "push"}},
+		"template":             []interface{}{map[string]interface{}{"ref": "build"}},
+	})
+
+	setManagedField(clients, d, meta, "service_account_name", "new", "spec", "serviceAccountName")
+	setManagedField(clients, d, meta, "bindings", flattenTriggerSpecBindings(nil), "spec", "bindings")
+
+	if got := d.Get("service_account_name"); got != "new" {
+		t.Errorf("service_account_name = %v, want the owned value new", got)
+	}
+	if got := d.Get("bindings").([]interface{}); len(got) != 0 {
+		t.Errorf("bindings = %v, want them cleared as they are not owned", got)
+	}
+}
+
+// testResourceData returns the data of a resource being created from config, with the raw
+// config Terraform passes along.
+func testResourceData(t *testing.T, r *schema.Resource, config map[string]interface{}) *schema.ResourceData {
+	t.Helper()
+	diff, err := schema.InternalMap(r.Schema).Diff(context.Background(), nil, terraform.NewResourceConfigRaw(config), nil, nil, false)
+	if err != nil {
+		t.Fatalf("Diff() error = %v", err)
+	}
+	diff.RawConfig = testRawConfig(t, r.Schema, config)
+	d, err := schema.InternalMap(r.Schema).Data(nil, diff)
+	if err != nil {
+		t.Fatalf("Data() error = %v", err)
+	}
+	return d
+}
+
+// testReadKeepsConfig clears the given attributes, runs read and checks that it sets them back
+// to their configured values, i.e. that they are flattened back to what they were built from.
+func testReadKeepsConfig(t *testing.T, d *schema.ResourceData, read schema.ReadFunc, clients providerClients, keys ...string) {
+	t.Helper()
+	want := map[string]interface{}{}
+	for _, key := range keys {
+		want[key] = d.Get(key)
+		if err := d.Set(key, nil); err != nil {
+			t.Fatalf("Set(%q) error = %v", key, err)
+		}
+	}
+	if err := read(d, clients); err != nil {
+		t.Fatalf("read error = %v", err)
+	}
+	if d.Id() == "" {
+		t.Fatalf("read removed the resource from state")
+	}
+	for _, key := range keys {
+		if got := d.Get(key); !reflect.DeepEqual(got, want[key]) {
+			t.Errorf("%s = %#v after read, want %#v", key, got, want[key])
+		}
+	}
+}
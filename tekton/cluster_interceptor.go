@@ -0,0 +1,140 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func resourceTektonClusterInterceptor() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTektonClusterInterceptorCreate,
+		Read:   resourceTektonClusterInterceptorRead,
+		Update: resourceTektonClusterInterceptorUpdate,
+		Delete: resourceTektonClusterInterceptorDelete,
+
+		CustomizeDiff: customdiff.Sequence(customizeClusterInterceptorDiff, customizeLabelsDiff),
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"client_config": interceptorClientConfigSchema(),
+			"address_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL the interceptor is reached at.",
+			},
+		}, metadataSchema()),
+	}
+}
+
+func resourceTektonClusterInterceptorCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+
+	clientConfig, err := getInterceptorClientConfig(d.Get("client_config").([]interface{}), "")
+	if err != nil {
+		return err
+	}
+
+	clusterInterceptor := &tektonv1alpha1.ClusterInterceptor{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, ""),
+		Spec: tektonv1alpha1.ClusterInterceptorSpec{
+			ClientConfig: clientConfig,
+		},
+	}
+
+	if _, err := clients.TektonTriggersClient.TriggersV1alpha1().ClusterInterceptors().Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "ClusterInterceptor", "", name)
+	}
+
+	patch, err := getApplyPatch(clusterInterceptor)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().ClusterInterceptors().Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create Tekton ClusterInterceptor: %v", err)
+	}
+
+	d.SetId(name)
+	return resourceTektonClusterInterceptorRead(d, m)
+}
+
+func resourceTektonClusterInterceptorRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+
+	clusterInterceptor, err := clients.TektonTriggersClient.TriggersV1alpha1().ClusterInterceptors().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	setObjectMeta(clients, d, clusterInterceptor.ObjectMeta)
+
+	clientConfig := flattenInterceptorClientConfig(clusterInterceptor.Spec.ClientConfig, "", nil)
+	setManagedField(clients, d, clusterInterceptor.ObjectMeta, "client_config", clientConfig, "spec", "clientConfig")
+
+	d.Set("address_url", getAddressURL(clusterInterceptor.Status.AddressStatus))
+
+	return nil
+}
+
+func resourceTektonClusterInterceptorUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+
+	clientConfig, err := getInterceptorClientConfig(d.Get("client_config").([]interface{}), "")
+	if err != nil {
+		return err
+	}
+
+	clusterInterceptor := &tektonv1alpha1.ClusterInterceptor{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, ""),
+		Spec: tektonv1alpha1.ClusterInterceptorSpec{
+			ClientConfig: clientConfig,
+		},
+	}
+
+	patch, err := getApplyPatch(clusterInterceptor)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().ClusterInterceptors().Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton ClusterInterceptor: %v", err)
+	}
+
+	return resourceTektonClusterInterceptorRead(d, m)
+}
+
+func resourceTektonClusterInterceptorDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+
+	err := clients.TektonTriggersClient.TriggersV1alpha1().ClusterInterceptors().Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete Tekton ClusterInterceptor: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// customizeClusterInterceptorDiff rejects a client_config service without a namespace at plan
+// time: a ClusterInterceptor has no namespace of its own to default it to.
+func customizeClusterInterceptorDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !rawPlanKnown(d, "client_config") {
+		return nil
+	}
+	_, err := getInterceptorClientConfig(d.Get("client_config").([]interface{}), "")
+	return err
+}
@@ -0,0 +1,119 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// resourceTektonClusterTask defines a Tekton ClusterTask, a cluster scoped Task.
+func resourceTektonClusterTask() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTektonClusterTaskCreate,
+		Read:   resourceTektonClusterTaskRead,
+		Update: resourceTektonClusterTaskUpdate,
+		Delete: resourceTektonClusterTaskDelete,
+
+		CustomizeDiff: customdiff.Sequence(customizeTaskDiff, customizeLabelsDiff),
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		}, taskSpecSchema(), metadataSchema()),
+	}
+}
+
+// resourceTektonClusterTaskCreate creates a Tekton ClusterTask.
+func resourceTektonClusterTaskCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+
+	spec, err := getTaskSpec(d.Get("steps").([]interface{}), d.Get("workspaces").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	clusterTask := &tektonv1beta1.ClusterTask{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, ""),
+		Spec:       spec,
+	}
+
+	if _, err := clients.TektonClient.TektonV1beta1().ClusterTasks().Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "ClusterTask", "", name)
+	}
+
+	patch, err := getApplyPatch(clusterTask)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1beta1().ClusterTasks().Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create Tekton ClusterTask: %v", err)
+	}
+
+	d.SetId(name)
+	return resourceTektonClusterTaskRead(d, m)
+}
+
+func resourceTektonClusterTaskRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+
+	clusterTask, err := clients.TektonClient.TektonV1beta1().ClusterTasks().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	setObjectMeta(clients, d, clusterTask.ObjectMeta)
+
+	d.Set("name", clusterTask.Name)
+
+	return setManagedTaskSpec(clients, d, clusterTask.ObjectMeta, clusterTask.Spec)
+}
+
+func resourceTektonClusterTaskUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+
+	spec, err := getTaskSpec(d.Get("steps").([]interface{}), d.Get("workspaces").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	clusterTask := &tektonv1beta1.ClusterTask{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, ""),
+		Spec:       spec,
+	}
+
+	patch, err := getApplyPatch(clusterTask)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1beta1().ClusterTasks().Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton ClusterTask: %v", err)
+	}
+
+	return resourceTektonClusterTaskRead(d, m)
+}
+
+func resourceTektonClusterTaskDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+
+	err := clients.TektonClient.TektonV1beta1().ClusterTasks().Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete Tekton ClusterTask: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
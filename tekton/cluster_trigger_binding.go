@@ -0,0 +1,116 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func resourceTektonClusterTriggerBinding() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTektonClusterTriggerBindingCreate,
+		Read:   resourceTektonClusterTriggerBindingRead,
+		Update: resourceTektonClusterTriggerBindingUpdate,
+		Delete: resourceTektonClusterTriggerBindingDelete,
+
+		CustomizeDiff: customizeLabelsDiff,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"bindings": triggerBindingParamsSchema(),
+		}, metadataSchema()),
+	}
+}
+
+func resourceTektonClusterTriggerBindingCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+
+	clusterTriggerBinding := &tektonv1alpha1.ClusterTriggerBinding{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, ""),
+		Spec: tektonv1alpha1.TriggerBindingSpec{
+			Params: getTriggerBindingParams(d.Get("bindings").([]interface{})),
+		},
+	}
+
+	if _, err := clients.TektonTriggersClient.TriggersV1alpha1().ClusterTriggerBindings().Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "ClusterTriggerBinding", "", name)
+	}
+
+	patch, err := getApplyPatch(clusterTriggerBinding)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().ClusterTriggerBindings().Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create Tekton ClusterTriggerBinding: %v", err)
+	}
+
+	d.SetId(name)
+	return resourceTektonClusterTriggerBindingRead(d, m)
+}
+
+func resourceTektonClusterTriggerBindingRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+
+	clusterTriggerBinding, err := clients.TektonTriggersClient.TriggersV1alpha1().ClusterTriggerBindings().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	setObjectMeta(clients, d, clusterTriggerBinding.ObjectMeta)
+
+	d.Set("name", clusterTriggerBinding.Name)
+	if managesField(clients, clusterTriggerBinding.ObjectMeta, "spec", "params") {
+		d.Set("bindings", flattenTriggerBindingParams(clusterTriggerBinding.Spec.Params))
+	} else {
+		d.Set("bindings", nil)
+	}
+
+	return nil
+}
+
+func resourceTektonClusterTriggerBindingUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+
+	clusterTriggerBinding := &tektonv1alpha1.ClusterTriggerBinding{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, ""),
+		Spec: tektonv1alpha1.TriggerBindingSpec{
+			Params: getTriggerBindingParams(d.Get("bindings").([]interface{})),
+		},
+	}
+
+	patch, err := getApplyPatch(clusterTriggerBinding)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().ClusterTriggerBindings().Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton ClusterTriggerBinding: %v", err)
+	}
+
+	return resourceTektonClusterTriggerBindingRead(d, m)
+}
+
+func resourceTektonClusterTriggerBindingDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+
+	err := clients.TektonTriggersClient.TriggersV1alpha1().ClusterTriggerBindings().Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete Tekton ClusterTriggerBinding: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
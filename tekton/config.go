@@ -0,0 +1,352 @@
+package tekton
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// tektonConfigKey is a key of a Tekton controller ConfigMap and its Terraform attribute.
+type tektonConfigKey struct {
+	Key          string
+	Attribute    string
+	Type         schema.ValueType
+	ValidateFunc schema.SchemaValidateFunc
+	Description  string
+}
+
+// tektonConfigMap is a Tekton controller ConfigMap, configured through a block of tekton_config.
+type tektonConfigMap struct {
+	Block string
+	Name  string
+	Keys  []tektonConfigKey
+}
+
+// tektonConfigMaps are the controller ConfigMaps of Tekton Pipelines and the keys tekton_config manages.
+var tektonConfigMaps = []tektonConfigMap{
+	{
+		Block: "feature_flags",
+		Name:  "feature-flags",
+		Keys: []tektonConfigKey{
+			{Key: "enable-api-fields", Attribute: "enable_api_fields", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"stable", "beta", "alpha"}, false), Description: "The stability level of the API fields that can be used."},
+			{Key: "coschedule", Attribute: "coschedule", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"workspaces", "pipelineruns", "isolate-pipelinerun", "disabled"}, false), Description: "How the pods of a PipelineRun are scheduled together by the affinity assistant."},
+			{Key: "disable-creds-init", Attribute: "disable_creds_init", Type: schema.TypeBool},
+			{Key: "await-sidecar-readiness", Attribute: "await_sidecar_readiness", Type: schema.TypeBool},
+			{Key: "running-in-environment-with-injected-sidecars", Attribute: "running_in_environment_with_injected_sidecars", Type: schema.TypeBool},
+			{Key: "require-git-ssh-secret-known-hosts", Attribute: "require_git_ssh_secret_known_hosts", Type: schema.TypeBool},
+			{Key: "send-cloudevents-for-runs", Attribute: "send_cloudevents_for_runs", Type: schema.TypeBool},
+			{Key: "trusted-resources-verification-no-match-policy", Attribute: "trusted_resources_verification_no_match_policy", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"ignore", "warn", "fail"}, false), Description: "What happens to a resource that matches no VerificationPolicy."},
+			{Key: "enable-provenance-in-status", Attribute: "enable_provenance_in_status", Type: schema.TypeBool},
+			{Key: "results-from", Attribute: "results_from", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"termination-message", "sidecar-logs"}, false)},
+			{Key: "max-result-size", Attribute: "max_result_size", Type: schema.TypeInt, ValidateFunc: validation.IntAtLeast(1), Description: "The maximum size of results in bytes when results_from is sidecar-logs."},
+			{Key: "set-security-context", Attribute: "set_security_context", Type: schema.TypeBool},
+			{Key: "keep-pod-on-cancel", Attribute: "keep_pod_on_cancel", Type: schema.TypeBool},
+			{Key: "enable-cel-in-whenexpression", Attribute: "enable_cel_in_whenexpression", Type: schema.TypeBool},
+			{Key: "enable-step-actions", Attribute: "enable_step_actions", Type: schema.TypeBool},
+			{Key: "enable-param-enum", Attribute: "enable_param_enum", Type: schema.TypeBool},
+			{Key: "enable-artifacts", Attribute: "enable_artifacts", Type: schema.TypeBool},
+			{Key: "enforce-nonfalsifiability", Attribute: "enforce_nonfalsifiability", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"none", "spire"}, false)},
+		},
+	},
+	{
+		Block: "defaults",
+		Name:  "config-defaults",
+		Keys: []tektonConfigKey{
+			{Key: "default-timeout-minutes", Attribute: "default_timeout_minutes", Type: schema.TypeInt, ValidateFunc: validation.IntAtLeast(0), Description: "The timeout of runs that don't set one. 0 means no timeout."},
+			{Key: "default-service-account", Attribute: "default_service_account", Type: schema.TypeString},
+			{Key: "default-managed-by-label-value", Attribute: "default_managed_by_label_value", Type: schema.TypeString},
+			{Key: "default-pod-template", Attribute: "default_pod_template", Type: schema.TypeString, Description: "The default pod template of runs as a YAML document."},
+			{Key: "default-affinity-assistant-pod-template", Attribute: "default_affinity_assistant_pod_template", Type: schema.TypeString},
+			{Key: "default-cloud-events-sink", Attribute: "default_cloud_events_sink", Type: schema.TypeString, ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"})},
+			{Key: "default-task-run-workspace-binding", Attribute: "default_task_run_workspace_binding", Type: schema.TypeString},
+			{Key: "default-max-matrix-combinations-count", Attribute: "default_max_matrix_combinations_count", Type: schema.TypeInt, ValidateFunc: validation.IntAtLeast(1)},
+			{Key: "default-forbidden-env", Attribute: "default_forbidden_env", Type: schema.TypeString, Description: "Comma separated environment variables that pod templates can't override."},
+			{Key: "default-resolver-type", Attribute: "default_resolver_type", Type: schema.TypeString},
+			{Key: "default-container-resource-requirements", Attribute: "default_container_resource_requirements", Type: schema.TypeString},
+			{Key: "default-imagepullbackoff-timeout", Attribute: "default_imagepullbackoff_timeout", Type: schema.TypeString, ValidateFunc: validateDuration},
+		},
+	},
+	{
+		Block: "artifact_pvc",
+		Name:  "config-artifact-pvc",
+		Keys: []tektonConfigKey{
+			{Key: "size", Attribute: "size", Type: schema.TypeString, Description: "The size of the PVC shared by the tasks of a PipelineRun, e.g. \"5Gi\"."},
+			{Key: "storageClassName", Attribute: "storage_class_name", Type: schema.TypeString},
+		},
+	},
+	{
+		Block: "observability",
+		Name:  "config-observability",
+		Keys: []tektonConfigKey{
+			{Key: "metrics.backend-destination", Attribute: "metrics_backend_destination", Type: schema.TypeString},
+			{Key: "metrics.taskrun.level", Attribute: "metrics_taskrun_level", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"task", "taskrun", "namespace"}, false)},
+			{Key: "metrics.taskrun.duration-type", Attribute: "metrics_taskrun_duration_type", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"histogram", "lastvalue"}, false)},
+			{Key: "metrics.pipelinerun.level", Attribute: "metrics_pipelinerun_level", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"pipeline", "pipelinerun", "namespace"}, false)},
+			{Key: "metrics.pipelinerun.duration-type", Attribute: "metrics_pipelinerun_duration_type", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"histogram", "lastvalue"}, false)},
+			{Key: "metrics.running-pipelinerun.level", Attribute: "metrics_running_pipelinerun_level", Type: schema.TypeString, ValidateFunc: validation.StringInSlice([]string{"pipeline", "pipelinerun", "namespace"}, false)},
+			{Key: "metrics.count.enable-reason", Attribute: "metrics_count_enable_reason", Type: schema.TypeBool},
+		},
+	},
+}
+
+// resourceTektonConfig manages keys of the Tekton Pipelines controller ConfigMaps. Only the
+// configured keys are applied, and only keys owned by the provider are checked for drift.
+func resourceTektonConfig() *schema.Resource {
+	configSchema := map[string]*schema.Schema{
+		"namespace": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "tekton-pipelines",
+			ForceNew:    true,
+			Description: "The namespace Tekton Pipelines is installed in.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "default",
+			ForceNew:    true,
+			Description: "Distinguishes several tekton_config resources in one namespace. Each applies its keys as its own field manager, so they must not set the same keys.",
+		},
+		"force_conflicts": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Take ownership of keys set by the Tekton install or by hand, which are owned by other field managers. Defaults to the provider's force_conflicts.",
+		},
+	}
+	for _, configMap := range tektonConfigMaps {
+		keys := map[string]*schema.Schema{}
+		for _, key := range configMap.Keys {
+			description := key.Description
+			if description == "" {
+				description = fmt.Sprintf("The %s key.", key.Key)
+			}
+			keys[key.Attribute] = &schema.Schema{
+				Type:         key.Type,
+				Optional:     true,
+				ValidateFunc: key.ValidateFunc,
+				Description:  description,
+			}
+		}
+		configSchema[configMap.Block] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: fmt.Sprintf("Keys of the %s ConfigMap.", configMap.Name),
+			Elem:        &schema.Resource{Schema: keys},
+		}
+	}
+
+	return &schema.Resource{
+		Create: resourceTektonConfigCreate,
+		Read:   resourceTektonConfigRead,
+		Update: resourceTektonConfigUpdate,
+		Delete: resourceTektonConfigDelete,
+
+		Schema: configSchema,
+	}
+}
+
+func resourceTektonConfigCreate(d *schema.ResourceData, m interface{}) error {
+	namespace := d.Get("namespace").(string)
+
+	if err := applyTektonConfig(d, m); err != nil {
+		return err
+	}
+
+	d.SetId(namespace + "/" + d.Get("name").(string))
+	return resourceTektonConfigRead(d, m)
+}
+
+func resourceTektonConfigRead(d *schema.ResourceData, m interface{}) error {
+	clients := getTektonConfigClients(d, m)
+	namespace := d.Get("namespace").(string)
+
+	for _, configMap := range tektonConfigMaps {
+		if len(d.Get(configMap.Block).([]interface{})) == 0 {
+			continue
+		}
+
+		cm, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), configMap.Name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				d.Set(configMap.Block, nil)
+				continue
+			}
+			return fmt.Errorf("failed to get ConfigMap %s: %v", configMap.Name, err)
+		}
+
+		values := map[string]interface{}{}
+		for _, key := range configMap.Keys {
+			value, ok := cm.Data[key.Key]
+			if !ok || !managesField(clients, cm.ObjectMeta, "data", key.Key) {
+				continue
+			}
+			switch key.Type {
+			case schema.TypeBool:
+				values[key.Attribute], _ = strconv.ParseBool(value)
+			case schema.TypeInt:
+				values[key.Attribute], _ = strconv.Atoi(value)
+			default:
+				values[key.Attribute] = value
+			}
+		}
+		d.Set(configMap.Block, []interface{}{values})
+	}
+
+	return nil
+}
+
+func resourceTektonConfigUpdate(d *schema.ResourceData, m interface{}) error {
+	if err := applyTektonConfig(d, m); err != nil {
+		return err
+	}
+	return resourceTektonConfigRead(d, m)
+}
+
+// resourceTektonConfigDelete gives up the provider's keys, which removes them from the
+// ConfigMaps so that Tekton falls back to their defaults.
+func resourceTektonConfigDelete(d *schema.ResourceData, m interface{}) error {
+	clients := getTektonConfigClients(d, m)
+	namespace := d.Get("namespace").(string)
+
+	for _, configMap := range tektonConfigMaps {
+		if len(d.Get(configMap.Block).([]interface{})) == 0 {
+			continue
+		}
+		// A missing ConfigMap holds no keys to release, and applying would create it.
+		exists, err := tektonConfigMapExists(clients, namespace, configMap.Name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		// Releasing keys cannot conflict with other managers, so no force is needed.
+		if err := applyTektonConfigMap(clients, namespace, configMap.Name, nil, false); err != nil {
+			return err
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// applyTektonConfig applies the configured keys of each ConfigMap. ConfigMaps whose block was
+// removed are applied without keys, which releases the keys the provider owned.
+func applyTektonConfig(d *schema.ResourceData, m interface{}) error {
+	clients := getTektonConfigClients(d, m)
+	namespace := d.Get("namespace").(string)
+	rawConfig := d.GetRawConfig()
+
+	force := clients.ForceConflicts
+	if rawConfigSet(rawConfig, "force_conflicts") {
+		force = d.Get("force_conflicts").(bool)
+	}
+
+	for _, configMap := range tektonConfigMaps {
+		oldBlock, newBlock := d.GetChange(configMap.Block)
+		if len(oldBlock.([]interface{})) == 0 && len(newBlock.([]interface{})) == 0 {
+			continue
+		}
+
+		data := map[string]string{}
+		if blocks := newBlock.([]interface{}); len(blocks) > 0 {
+			values, _ := blocks[0].(map[string]interface{})
+			for _, key := range configMap.Keys {
+				if !configAttributeSet(rawConfig, configMap.Block, key.Attribute) {
+					continue
+				}
+				data[key.Key] = fmt.Sprint(values[key.Attribute])
+			}
+		}
+
+		// The ConfigMaps belong to the Tekton install: they are only changed, never created.
+		exists, err := tektonConfigMapExists(clients, namespace, configMap.Name)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			if len(newBlock.([]interface{})) == 0 {
+				continue
+			}
+			return fmt.Errorf("ConfigMap %s not found in namespace %s: is Tekton Pipelines installed there?", configMap.Name, namespace)
+		}
+
+		if err := applyTektonConfigMap(clients, namespace, configMap.Name, data, force); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tektonConfigMapExists reports whether a Tekton ConfigMap exists.
+func tektonConfigMapExists(clients providerClients, namespace, name string) (bool, error) {
+	_, err := clients.KubeClient.CoreV1().ConfigMaps(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get ConfigMap %s: %v", name, err)
+	}
+	return true, nil
+}
+
+// getTektonConfigClients returns the provider clients with the field manager of a tekton_config
+// resource. The default resource of a namespace uses the provider's field manager, others append
+// their name so that each owns only its own keys.
+func getTektonConfigClients(d *schema.ResourceData, m interface{}) providerClients {
+	clients := m.(providerClients)
+	if name := d.Get("name").(string); name != "" && name != "default" {
+		clients.FieldManager = clients.FieldManager + "-" + name
+	}
+	return clients
+}
+
+// configAttributeSet reports whether an attribute of a block is set in the configuration, so
+// that false and 0 can be told apart from unset keys.
+func configAttributeSet(rawConfig cty.Value, block, attribute string) bool {
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return false
+	}
+	blocks := rawConfig.GetAttr(block)
+	if blocks.IsNull() || !blocks.IsKnown() || blocks.LengthInt() == 0 {
+		return false
+	}
+	return !blocks.Index(cty.NumberIntVal(0)).GetAttr(attribute).IsNull()
+}
+
+func applyTektonConfigMap(clients providerClients, namespace, name string, data map[string]string, force bool) error {
+	configMap := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      name,
+			"namespace": namespace,
+		},
+	}
+	if len(data) > 0 {
+		configMap["data"] = data
+	}
+
+	patch, err := json.Marshal(configMap)
+	if err != nil {
+		return fmt.Errorf("failed to encode ConfigMap %s: %v", name, err)
+	}
+
+	options := getApplyOptions(clients)
+	options.Force = &force
+	_, err = clients.KubeClient.CoreV1().ConfigMaps(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, options)
+	if err != nil {
+		return fmt.Errorf("failed to apply ConfigMap %s: %v", name, err)
+	}
+	return nil
+}
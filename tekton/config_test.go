@@ -0,0 +1,48 @@
+package tekton
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTektonConfigMissingConfigMap(t *testing.T) {
+	config := map[string]interface{}{
+		"feature_flags": []interface{}{map[string]interface{}{"enable_step_actions": true}},
+	}
+
+	t.Run("create", func(t *testing.T) {
+		kubeClient := kubefake.NewSimpleClientset()
+		d := testResourceData(t, resourceTektonConfig(), config)
+
+		err := resourceTektonConfigCreate(d, providerClients{KubeClient: kubeClient, FieldManager: "terraform"})
+		if err == nil || !strings.Contains(err.Error(), "feature-flags not found") {
+			t.Fatalf("resourceTektonConfigCreate() error = %v, want the missing ConfigMap reported", err)
+		}
+		if _, err := kubeClient.CoreV1().ConfigMaps("tekton-pipelines").Get(context.Background(), "feature-flags", metav1.GetOptions{}); !errors.IsNotFound(err) {
+			t.Errorf("Get() error = %v, want the ConfigMap not to be created", err)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		kubeClient := kubefake.NewSimpleClientset()
+		d := testResourceData(t, resourceTektonConfig(), config)
+		d.SetId("tekton-pipelines/default")
+
+		if err := resourceTektonConfigDelete(d, providerClients{KubeClient: kubeClient, FieldManager: "terraform"}); err != nil {
+			t.Fatalf("resourceTektonConfigDelete() error = %v", err)
+		}
+		for _, action := range kubeClient.Actions() {
+			if action.GetVerb() != "get" {
+				t.Errorf("unexpected %s of %s, want the missing ConfigMap left alone", action.GetVerb(), action.GetResource().Resource)
+			}
+		}
+		if d.Id() != "" {
+			t.Errorf("Id() = %q, want the resource removed from state", d.Id())
+		}
+	})
+}
@@ -0,0 +1,271 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"knative.dev/pkg/apis"
+)
+
+// resourceTektonCustomRun defines a Tekton CustomRun, a run of a custom task controller.
+func resourceTektonCustomRun() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTektonCustomRunCreate,
+		Read:   resourceTektonCustomRunRead,
+		Update: resourceTektonCustomRunUpdate,
+		Delete: resourceTektonCustomRunDelete,
+
+		CustomizeDiff: customizeRunDiff,
+
+		Schema: mergeSchemas(runTriggerSchema(), runDestroySchema(), map[string]*schema.Schema{
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the run's Succeeded condition: Unknown, True or False.",
+			},
+			"reason": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The reason of the run's Succeeded condition.",
+			},
+			"results": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The results reported by the custom task controller.",
+			},
+		}, forceNewSchemas(map[string]*schema.Schema{
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+			},
+			"custom_ref": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"custom_ref", "custom_spec"},
+				Description:  "The custom task to run.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"kind": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of a custom task object. Empty for controllers that only need the kind.",
+						},
+					},
+				},
+			},
+			"custom_spec": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"custom_ref", "custom_spec"},
+				Description:  "An inline custom task definition to run instead of referencing one.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"api_version": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"kind": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"spec": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validation.StringIsJSON,
+							DiffSuppressFunc: suppressEquivalentDocument,
+							Description:      "The spec of the custom task as a JSON document, e.g. jsonencode({ ... }).",
+						},
+					},
+				},
+			},
+			"params": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+					},
+				},
+			},
+			"service_account_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+			},
+			"timeout": durationSchema("Timeout for the CustomRun, e.g. \"1h\"."),
+			"retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "How many times the custom task controller should retry the run on failure.",
+			},
+		}), forceNewSchemas(runMetadataSchema())),
+	}
+}
+
+// resourceTektonCustomRunCreate creates a Tekton CustomRun.
+func resourceTektonCustomRunCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	namespace := d.Get("namespace").(string)
+
+	customRun, err := getCustomRun(clients.Metadata, d)
+	if err != nil {
+		return err
+	}
+
+	created, err := clients.TektonClient.TektonV1beta1().CustomRuns(namespace).Create(context.Background(), customRun, metav1.CreateOptions{FieldManager: clients.FieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to create Tekton CustomRun: %v", err)
+	}
+
+	d.SetId(created.Name)
+	d.Set("name", created.Name)
+
+	return resourceTektonCustomRunRead(d, m)
+}
+
+// resourceTektonCustomRunRead reads the state of a Tekton CustomRun.
+func resourceTektonCustomRunRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	customRun, err := clients.TektonClient.TektonV1beta1().CustomRuns(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	status, reason := "", ""
+	if condition := customRun.Status.GetCondition(apis.ConditionSucceeded); condition != nil {
+		status, reason = string(condition.Status), condition.Reason
+	}
+	results := map[string]interface{}{}
+	for _, result := range customRun.Status.Results {
+		results[result.Name] = result.Value
+	}
+
+	d.Set("status", status)
+	d.Set("reason", reason)
+	d.Set("results", results)
+
+	return nil
+}
+
+// resourceTektonCustomRunUpdate starts a new CustomRun when triggers change in generate_name mode.
+func resourceTektonCustomRunUpdate(d *schema.ResourceData, m interface{}) error {
+	if !d.HasChange("triggers") {
+		return resourceTektonCustomRunRead(d, m)
+	}
+
+	clients := m.(providerClients)
+	previous := d.Id()
+
+	if err := resourceTektonCustomRunCreate(d, m); err != nil {
+		return err
+	}
+
+	if !d.Get("keep_previous_runs").(bool) {
+		if err := destroyTektonCustomRun(clients, d, previous); err != nil {
+			return fmt.Errorf("failed to clean up previous Tekton CustomRun %s: %v", previous, err)
+		}
+	}
+
+	return nil
+}
+
+// resourceTektonCustomRunDelete deletes a Tekton CustomRun.
+func resourceTektonCustomRunDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+
+	if err := destroyTektonCustomRun(clients, d, d.Id()); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// destroyTektonCustomRun deletes, cancels or keeps a CustomRun according to on_destroy. It is
+// used both on destroy and for the previous run when triggers start a new one.
+func destroyTektonCustomRun(clients providerClients, d *schema.ResourceData, name string) error {
+	namespace := d.Get("namespace").(string)
+
+	switch d.Get("on_destroy").(string) {
+	case onDestroyKeep:
+		return nil
+	case onDestroyCancel:
+		timeout, _ := time.ParseDuration(d.Get("cancel_timeout").(string))
+		return cancelCustomRun(clients, namespace, name, timeout)
+	}
+
+	err := clients.TektonClient.TektonV1beta1().CustomRuns(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete Tekton CustomRun: %v", err)
+	}
+	return nil
+}
+
+// Helper function to build a Tekton CustomRun from the resource configuration
+func getCustomRun(config metadataConfig, d *schema.ResourceData) (*tektonv1beta1.CustomRun, error) {
+	timeout, err := parseDuration(d.Get("timeout").(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout: %v", err)
+	}
+
+	customRun := &tektonv1beta1.CustomRun{
+		ObjectMeta: getRunObjectMeta(config, d),
+		Spec: tektonv1beta1.CustomRunSpec{
+			ServiceAccountName: d.Get("service_account_name").(string),
+			Params:             getStepParams(d.Get("params").([]interface{})),
+			Timeout:            timeout,
+			Retries:            d.Get("retries").(int),
+		},
+	}
+
+	if v := d.Get("custom_spec").([]interface{}); len(v) > 0 && v[0] != nil {
+		specData := v[0].(map[string]interface{})
+		customRun.Spec.CustomSpec = &tektonv1beta1.EmbeddedCustomRunSpec{
+			TypeMeta: runtime.TypeMeta{
+				APIVersion: specData["api_version"].(string),
+				Kind:       specData["kind"].(string),
+			},
+			Spec: runtime.RawExtension{Raw: []byte(specData["spec"].(string))},
+		}
+	} else if v := d.Get("custom_ref").([]interface{}); len(v) > 0 && v[0] != nil {
+		refData := v[0].(map[string]interface{})
+		customRun.Spec.CustomRef = &tektonv1beta1.TaskRef{
+			APIVersion: refData["api_version"].(string),
+			Kind:       tektonv1beta1.TaskKind(refData["kind"].(string)),
+			Name:       refData["name"].(string),
+		}
+	}
+
+	return customRun, nil
+}
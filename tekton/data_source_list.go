@@ -0,0 +1,365 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+const (
+	runStatusRunning   = "running"
+	runStatusSucceeded = "succeeded"
+	runStatusFailed    = "failed"
+)
+
+// dataSourceTektonTasks lists Tekton Tasks.
+func dataSourceTektonTasks() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceTektonTasksRead,
+		Schema: listDataSourceSchema(false),
+	}
+}
+
+// dataSourceTektonPipelines lists Tekton Pipelines.
+func dataSourceTektonPipelines() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceTektonPipelinesRead,
+		Schema: listDataSourceSchema(false),
+	}
+}
+
+// dataSourceTektonTaskRuns lists Tekton TaskRuns.
+func dataSourceTektonTaskRuns() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceTektonTaskRunsRead,
+		Schema: listDataSourceSchema(true),
+	}
+}
+
+// dataSourceTektonPipelineRuns lists Tekton PipelineRuns.
+func dataSourceTektonPipelineRuns() *schema.Resource {
+	return &schema.Resource{
+		Read:   dataSourceTektonPipelineRunsRead,
+		Schema: listDataSourceSchema(true),
+	}
+}
+
+// listDataSourceSchema defines the filters and items of a list data source. Run data sources can
+// also filter by status and return the status and results of each run.
+func listDataSourceSchema(runs bool) map[string]*schema.Schema {
+	item := map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"namespace": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"creation_timestamp": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The creation time in RFC 3339 format.",
+		},
+		"labels": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+
+	listSchema := map[string]*schema.Schema{
+		"namespace": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "default",
+		},
+		"all_namespaces": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "List objects in all namespaces instead of namespace.",
+		},
+		"label_selector": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateLabelSelector,
+			Description:  "A label selector, e.g. \"app=release,env!=dev\".",
+		},
+		"field_selector": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateFieldSelector,
+			Description:  "A field selector, e.g. \"metadata.name=release\".",
+		},
+		"names": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The names of the items, newest first.",
+		},
+		"items": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The matching objects, newest first.",
+			Elem:        &schema.Resource{Schema: item},
+		},
+	}
+
+	if runs {
+		listSchema["status"] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice([]string{runStatusRunning, runStatusSucceeded, runStatusFailed}, false),
+			Description:  "Only list runs that are \"running\", \"succeeded\" or \"failed\".",
+		}
+
+		item["status"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The run's status: running, succeeded or failed.",
+		}
+		item["start_time"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+		item["completion_time"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+		item["conditions"] = &schema.Schema{
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"status": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"reason": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"message": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		}
+		item["results"] = &schema.Schema{
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The run's results; array and object results are JSON encoded.",
+		}
+	}
+
+	return listSchema
+}
+
+func dataSourceTektonTasksRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	namespace, options := getListOptions(d)
+
+	tasks, err := clients.TektonClient.TektonV1beta1().Tasks(namespace).List(context.Background(), options)
+	if err != nil {
+		return fmt.Errorf("failed to list Tekton Tasks: %v", err)
+	}
+
+	var items []map[string]interface{}
+	for _, task := range tasks.Items {
+		items = append(items, flattenListItem(task.ObjectMeta))
+	}
+
+	return setListItems(d, "tasks", namespace, items)
+}
+
+func dataSourceTektonPipelinesRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	namespace, options := getListOptions(d)
+
+	pipelines, err := clients.TektonClient.TektonV1beta1().Pipelines(namespace).List(context.Background(), options)
+	if err != nil {
+		return fmt.Errorf("failed to list Tekton Pipelines: %v", err)
+	}
+
+	var items []map[string]interface{}
+	for _, pipeline := range pipelines.Items {
+		items = append(items, flattenListItem(pipeline.ObjectMeta))
+	}
+
+	return setListItems(d, "pipelines", namespace, items)
+}
+
+func dataSourceTektonTaskRunsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	namespace, options := getListOptions(d)
+
+	taskRuns, err := clients.TektonClient.TektonV1beta1().TaskRuns(namespace).List(context.Background(), options)
+	if err != nil {
+		return fmt.Errorf("failed to list Tekton TaskRuns: %v", err)
+	}
+
+	var items []map[string]interface{}
+	for _, taskRun := range taskRuns.Items {
+		results := map[string]tektonv1beta1.ResultValue{}
+		for _, result := range taskRun.Status.TaskRunResults {
+			results[result.Name] = result.Value
+		}
+		item, err := flattenRunListItem(taskRun.ObjectMeta, taskRun.Status.Status, taskRun.Status.StartTime, taskRun.Status.CompletionTime, results)
+		if err != nil {
+			return err
+		}
+		if status := d.Get("status").(string); status == "" || item["status"] == status {
+			items = append(items, item)
+		}
+	}
+
+	return setListItems(d, "taskruns", namespace, items)
+}
+
+func dataSourceTektonPipelineRunsRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	namespace, options := getListOptions(d)
+
+	pipelineRuns, err := clients.TektonClient.TektonV1beta1().PipelineRuns(namespace).List(context.Background(), options)
+	if err != nil {
+		return fmt.Errorf("failed to list Tekton PipelineRuns: %v", err)
+	}
+
+	var items []map[string]interface{}
+	for _, pipelineRun := range pipelineRuns.Items {
+		results := map[string]tektonv1beta1.ResultValue{}
+		for _, result := range pipelineRun.Status.PipelineResults {
+			results[result.Name] = result.Value
+		}
+		item, err := flattenRunListItem(pipelineRun.ObjectMeta, pipelineRun.Status.Status, pipelineRun.Status.StartTime, pipelineRun.Status.CompletionTime, results)
+		if err != nil {
+			return err
+		}
+		if status := d.Get("status").(string); status == "" || item["status"] == status {
+			items = append(items, item)
+		}
+	}
+
+	return setListItems(d, "pipelineruns", namespace, items)
+}
+
+// getListOptions returns the namespace to list, empty for all namespaces, and the selectors.
+func getListOptions(d *schema.ResourceData) (string, metav1.ListOptions) {
+	namespace := d.Get("namespace").(string)
+	if d.Get("all_namespaces").(bool) {
+		namespace = metav1.NamespaceAll
+	}
+	return namespace, metav1.ListOptions{
+		LabelSelector: d.Get("label_selector").(string),
+		FieldSelector: d.Get("field_selector").(string),
+	}
+}
+
+func flattenListItem(meta metav1.ObjectMeta) map[string]interface{} {
+	return map[string]interface{}{
+		"name":               meta.Name,
+		"namespace":          meta.Namespace,
+		"creation_timestamp": meta.CreationTimestamp.UTC().Format(time.RFC3339),
+		"labels":             meta.Labels,
+	}
+}
+
+func flattenRunListItem(meta metav1.ObjectMeta, status duckv1.Status, startTime, completionTime *metav1.Time, results map[string]tektonv1beta1.ResultValue) (map[string]interface{}, error) {
+	item := flattenListItem(meta)
+
+	item["status"] = runStatusRunning
+	if condition := status.GetCondition(apis.ConditionSucceeded); condition != nil {
+		if condition.IsTrue() {
+			item["status"] = runStatusSucceeded
+		} else if condition.IsFalse() {
+			item["status"] = runStatusFailed
+		}
+	}
+
+	var conditions []interface{}
+	for _, condition := range status.Conditions {
+		conditions = append(conditions, map[string]interface{}{
+			"type":    string(condition.Type),
+			"status":  string(condition.Status),
+			"reason":  condition.Reason,
+			"message": condition.Message,
+		})
+	}
+	item["conditions"] = conditions
+
+	flattenedResults := map[string]interface{}{}
+	for name, result := range results {
+		value, err := flattenParamValue(&result)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode result %s of %s: %v", name, meta.Name, err)
+		}
+		flattenedResults[name] = value
+	}
+	item["results"] = flattenedResults
+
+	item["start_time"] = ""
+	if startTime != nil {
+		item["start_time"] = startTime.UTC().Format(time.RFC3339)
+	}
+	item["completion_time"] = ""
+	if completionTime != nil {
+		item["completion_time"] = completionTime.UTC().Format(time.RFC3339)
+	}
+
+	return item, nil
+}
+
+// setListItems sorts the items newest first and sets them in state.
+func setListItems(d *schema.ResourceData, kind, namespace string, items []map[string]interface{}) error {
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i]["creation_timestamp"] != items[j]["creation_timestamp"] {
+			return items[i]["creation_timestamp"].(string) > items[j]["creation_timestamp"].(string)
+		}
+		return items[i]["name"].(string) < items[j]["name"].(string)
+	})
+
+	var names, state []interface{}
+	for _, item := range items {
+		names = append(names, item["name"])
+		state = append(state, item)
+	}
+
+	if namespace == metav1.NamespaceAll {
+		namespace = "*"
+	}
+	d.SetId(kind + "/" + namespace)
+	if err := d.Set("names", names); err != nil {
+		return err
+	}
+	return d.Set("items", state)
+}
+
+func validateLabelSelector(v interface{}, k string) ([]string, []error) {
+	if _, err := labels.Parse(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q is not a valid label selector: %v", k, err)}
+	}
+	return nil, nil
+}
+
+func validateFieldSelector(v interface{}, k string) ([]string, []error) {
+	if _, err := fields.ParseSelector(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q is not a valid field selector: %v", k, err)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,151 @@
+package tekton
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func TestFlattenRunListItem(t *testing.T) {
+	started := metav1.NewTime(time.Date(2024, 5, 1, 10, 0, 0, 0, time.FixedZone("CEST", 2*60*60)))
+	completed := metav1.NewTime(time.Date(2024, 5, 1, 8, 5, 0, 0, time.UTC))
+	succeeded := func(status corev1.ConditionStatus) duckv1.Status {
+		return duckv1.Status{Conditions: duckv1.Conditions{{
+			Type:    apis.ConditionSucceeded,
+			Status:  status,
+			Reason:  "Reason",
+			Message: "message",
+		}}}
+	}
+
+	tests := []struct {
+		name               string
+		status             duckv1.Status
+		startTime          *metav1.Time
+		completionTime     *metav1.Time
+		results            map[string]tektonv1beta1.ResultValue
+		wantStatus         string
+		wantConditions     []interface{}
+		wantResults        map[string]interface{}
+		wantStartTime      string
+		wantCompletionTime string
+	}{
+		{
+			name:        "no conditions",
+			wantStatus:  runStatusRunning,
+			wantResults: map[string]interface{}{},
+		},
+		{
+			name:      "unknown condition",
+			status:    succeeded(corev1.ConditionUnknown),
+			startTime: &started,
+			wantConditions: []interface{}{map[string]interface{}{
+				"type": "Succeeded", "status": "Unknown", "reason": "Reason", "message": "message",
+			}},
+			wantStatus:    runStatusRunning,
+			wantResults:   map[string]interface{}{},
+			wantStartTime: "2024-05-01T08:00:00Z",
+		},
+		{
+			name:           "succeeded with results",
+			status:         succeeded(corev1.ConditionTrue),
+			startTime:      &started,
+			completionTime: &completed,
+			results: map[string]tektonv1beta1.ResultValue{
+				"digest": *tektonv1beta1.NewStructuredValues("sha256:abc"),
+				"tags":   *tektonv1beta1.NewStructuredValues("latest", "v1"),
+			},
+			wantStatus: runStatusSucceeded,
+			wantConditions: []interface{}{map[string]interface{}{
+				"type": "Succeeded", "status": "True", "reason": "Reason", "message": "message",
+			}},
+			wantResults:        map[string]interface{}{"digest": "sha256:abc", "tags": `["latest","v1"]`},
+			wantStartTime:      "2024-05-01T08:00:00Z",
+			wantCompletionTime: "2024-05-01T08:05:00Z",
+		},
+		{
+			name:           "failed",
+			status:         succeeded(corev1.ConditionFalse),
+			completionTime: &completed,
+			wantStatus:     runStatusFailed,
+			wantConditions: []interface{}{map[string]interface{}{
+				"type": "Succeeded", "status": "False", "reason": "Reason", "message": "message",
+			}},
+			wantResults:        map[string]interface{}{},
+			wantCompletionTime: "2024-05-01T08:05:00Z",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta := metav1.ObjectMeta{Name: "build-abc", Namespace: "ci"}
+			item, err := flattenRunListItem(meta, tt.status, tt.startTime, tt.completionTime, tt.results)
+			if err != nil {
+				t.Fatalf("flattenRunListItem() error = %v", err)
+			}
+
+			if item["name"] != "build-abc" || item["namespace"] != "ci" {
+				t.Errorf("name and namespace = %v %v, want build-abc ci", item["name"], item["namespace"])
+			}
+			if item["status"] != tt.wantStatus {
+				t.Errorf("status = %v, want %v", item["status"], tt.wantStatus)
+			}
+			if got := item["conditions"].([]interface{}); !reflect.DeepEqual(got, tt.wantConditions) {
+				t.Errorf("conditions = %v, want %v", got, tt.wantConditions)
+			}
+			if got := item["results"]; !reflect.DeepEqual(got, tt.wantResults) {
+				t.Errorf("results = %v, want %v", got, tt.wantResults)
+			}
+			if item["start_time"] != tt.wantStartTime {
+				t.Errorf("start_time = %v, want %v", item["start_time"], tt.wantStartTime)
+			}
+			if item["completion_time"] != tt.wantCompletionTime {
+				t.Errorf("completion_time = %v, want %v", item["completion_time"], tt.wantCompletionTime)
+			}
+		})
+	}
+}
+
+func TestSetListItems(t *testing.T) {
+	older := metav1.NewTime(time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC))
+	newer := metav1.NewTime(time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC))
+	items := []map[string]interface{}{
+		flattenListItem(metav1.ObjectMeta{Name: "old", Namespace: "ci", CreationTimestamp: older}),
+		flattenListItem(metav1.ObjectMeta{Name: "new-b", Namespace: "ci", CreationTimestamp: newer}),
+		flattenListItem(metav1.ObjectMeta{Name: "new-a", Namespace: "ci", CreationTimestamp: newer}),
+	}
+
+	tests := []struct {
+		name      string
+		namespace string
+		wantID    string
+	}{
+		{name: "namespace", namespace: "ci", wantID: "Task/ci"},
+		{name: "all namespaces", namespace: metav1.NamespaceAll, wantID: "Task/*"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceTektonTasks().Schema, map[string]interface{}{})
+
+			if err := setListItems(d, "Task", tt.namespace, append([]map[string]interface{}(nil), items...)); err != nil {
+				t.Fatalf("setListItems() error = %v", err)
+			}
+			if d.Id() != tt.wantID {
+				t.Errorf("id = %q, want %q", d.Id(), tt.wantID)
+			}
+			want := []interface{}{"new-a", "new-b", "old"}
+			if got := d.Get("names").([]interface{}); !reflect.DeepEqual(got, want) {
+				t.Errorf("names = %v, want %v", got, want)
+			}
+			if got := d.Get("items.0.creation_timestamp"); got != "2024-05-02T08:00:00Z" {
+				t.Errorf("items.0.creation_timestamp = %v, want 2024-05-02T08:00:00Z", got)
+			}
+		})
+	}
+}
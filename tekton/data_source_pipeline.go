@@ -0,0 +1,255 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dataSourceTektonPipeline reads an existing Tekton Pipeline.
+func dataSourceTektonPipeline() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTektonPipelineRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+			},
+			"labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"annotations": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"params": dataSourceParamsSchema(),
+			"results": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The expression the result is taken from, e.g. $(tasks.build.results.digest).",
+						},
+					},
+				},
+			},
+			"workspaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"optional": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"tasks":   dataSourcePipelineTasksSchema(),
+			"finally": dataSourcePipelineTasksSchema(),
+		},
+	}
+}
+
+// dataSourcePipelineTasksSchema defines the flattened tasks of a Pipeline read by a data source.
+func dataSourcePipelineTasksSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"task_ref_name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"task_ref_kind": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"task_ref_api_version": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"run_after": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"params": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"value": {
+								Type:        schema.TypeString,
+								Computed:    true,
+								Description: "The value; array and object values are JSON encoded.",
+							},
+						},
+					},
+				},
+				"workspaces": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+							"workspace_ref": {
+								Type:     schema.TypeString,
+								Computed: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTektonPipelineRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+	namespace := d.Get("namespace").(string)
+
+	pipeline, err := clients.TektonClient.TektonV1beta1().Pipelines(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Tekton Pipeline: %v", err)
+	}
+
+	params, err := flattenParamSpecs(pipeline.Spec.Params)
+	if err != nil {
+		return err
+	}
+	tasks, err := flattenPipelineTasks(pipeline.Spec.Tasks)
+	if err != nil {
+		return err
+	}
+	finally, err := flattenPipelineTasks(pipeline.Spec.Finally)
+	if err != nil {
+		return err
+	}
+
+	var results []interface{}
+	for _, result := range pipeline.Spec.Results {
+		value, err := flattenParamValue(&result.Value)
+		if err != nil {
+			return fmt.Errorf("failed to encode the value of result %s: %v", result.Name, err)
+		}
+		results = append(results, map[string]interface{}{
+			"name":        result.Name,
+			"type":        string(result.Type),
+			"description": result.Description,
+			"value":       value,
+		})
+	}
+
+	var workspaces []interface{}
+	for _, workspace := range pipeline.Spec.Workspaces {
+		workspaces = append(workspaces, map[string]interface{}{
+			"name":        workspace.Name,
+			"description": workspace.Description,
+			"optional":    workspace.Optional,
+		})
+	}
+
+	d.SetId(namespace + "/" + name)
+	d.Set("labels", pipeline.Labels)
+	d.Set("annotations", pipeline.Annotations)
+	d.Set("description", pipeline.Spec.Description)
+	d.Set("params", params)
+	d.Set("results", results)
+	d.Set("workspaces", workspaces)
+	d.Set("tasks", tasks)
+	d.Set("finally", finally)
+
+	return nil
+}
+
+func flattenPipelineTasks(pipelineTasks []tektonv1beta1.PipelineTask) ([]interface{}, error) {
+	var tasks []interface{}
+	for _, pipelineTask := range pipelineTasks {
+		var params []interface{}
+		for _, param := range pipelineTask.Params {
+			value, err := flattenParamValue(&param.Value)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode param %s of task %s: %v", param.Name, pipelineTask.Name, err)
+			}
+			params = append(params, map[string]interface{}{
+				"name":  param.Name,
+				"value": value,
+			})
+		}
+
+		var workspaces []interface{}
+		for _, workspace := range pipelineTask.Workspaces {
+			workspaces = append(workspaces, map[string]interface{}{
+				"name":          workspace.Name,
+				"workspace_ref": workspace.Workspace,
+			})
+		}
+
+		task := map[string]interface{}{
+			"name":       pipelineTask.Name,
+			"run_after":  pipelineTask.RunAfter,
+			"params":     params,
+			"workspaces": workspaces,
+		}
+		if pipelineTask.TaskRef != nil {
+			task["task_ref_name"] = pipelineTask.TaskRef.Name
+			task["task_ref_kind"] = string(pipelineTask.TaskRef.Kind)
+			task["task_ref_api_version"] = pipelineTask.TaskRef.APIVersion
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
@@ -0,0 +1,310 @@
+package tekton
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// dataSourceTektonTask reads an existing Tekton Task, e.g. a catalog Task installed by another team.
+func dataSourceTektonTask() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTektonTaskRead,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+			},
+		}, dataSourceTaskSpecSchema()),
+	}
+}
+
+// dataSourceTektonClusterTask reads an existing Tekton ClusterTask.
+func dataSourceTektonClusterTask() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTektonClusterTaskRead,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		}, dataSourceTaskSpecSchema()),
+	}
+}
+
+func dataSourceTektonTaskRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+	namespace := d.Get("namespace").(string)
+
+	task, err := clients.TektonClient.TektonV1beta1().Tasks(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Tekton Task: %v", err)
+	}
+
+	d.SetId(namespace + "/" + name)
+	return setTaskSpec(d, task.ObjectMeta, task.Spec)
+}
+
+func dataSourceTektonClusterTaskRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+
+	clusterTask, err := clients.TektonClient.TektonV1beta1().ClusterTasks().Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Tekton ClusterTask: %v", err)
+	}
+
+	d.SetId(name)
+	return setTaskSpec(d, clusterTask.ObjectMeta, clusterTask.Spec)
+}
+
+// dataSourceTaskSpecSchema defines the flattened spec of a Task or ClusterTask read by a data source.
+func dataSourceTaskSpecSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"labels": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"annotations": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"description": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"params": dataSourceParamsSchema(),
+		"results": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"type": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"workspaces": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"mount_path": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"read_only": {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+					"optional": {
+						Type:     schema.TypeBool,
+						Computed: true,
+					},
+				},
+			},
+		},
+		"steps": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"image": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"command": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"args": {
+						Type:     schema.TypeList,
+						Computed: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"script": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"working_dir": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"ref_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the StepAction the step references, if any.",
+					},
+				},
+			},
+		},
+	}
+}
+
+// dataSourceParamsSchema defines the flattened params of a Task or Pipeline read by a data source.
+func dataSourceParamsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"description": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"default": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The default value; array and object defaults are JSON encoded.",
+				},
+				"has_default": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the param has a default. Params without one must be given a value.",
+				},
+				"enum": {
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func setTaskSpec(d *schema.ResourceData, meta metav1.ObjectMeta, spec tektonv1beta1.TaskSpec) error {
+	var results []interface{}
+	for _, result := range spec.Results {
+		results = append(results, map[string]interface{}{
+			"name":        result.Name,
+			"type":        string(result.Type),
+			"description": result.Description,
+		})
+	}
+
+	var workspaces []interface{}
+	for _, workspace := range spec.Workspaces {
+		workspaces = append(workspaces, map[string]interface{}{
+			"name":        workspace.Name,
+			"description": workspace.Description,
+			"mount_path":  workspace.MountPath,
+			"read_only":   workspace.ReadOnly,
+			"optional":    workspace.Optional,
+		})
+	}
+
+	var steps []interface{}
+	for _, step := range spec.Steps {
+		refName := ""
+		if step.Ref != nil {
+			refName = step.Ref.Name
+		}
+		steps = append(steps, map[string]interface{}{
+			"name":        step.Name,
+			"image":       step.Image,
+			"command":     step.Command,
+			"args":        step.Args,
+			"script":      step.Script,
+			"working_dir": step.WorkingDir,
+			"ref_name":    refName,
+		})
+	}
+
+	params, err := flattenParamSpecs(spec.Params)
+	if err != nil {
+		return err
+	}
+
+	d.Set("labels", meta.Labels)
+	d.Set("annotations", meta.Annotations)
+	d.Set("description", spec.Description)
+	d.Set("params", params)
+	d.Set("results", results)
+	d.Set("workspaces", workspaces)
+	d.Set("steps", steps)
+
+	return nil
+}
+
+func flattenParamSpecs(specs tektonv1beta1.ParamSpecs) ([]interface{}, error) {
+	var params []interface{}
+	for _, spec := range specs {
+		value, err := flattenParamValue(spec.Default)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the default of param %s: %v", spec.Name, err)
+		}
+		params = append(params, map[string]interface{}{
+			"name":        spec.Name,
+			"type":        string(spec.Type),
+			"description": spec.Description,
+			"default":     value,
+			"has_default": spec.Default != nil,
+			"enum":        spec.Enum,
+		})
+	}
+	return params, nil
+}
+
+// flattenParamValue returns a string value as is, and array and object values JSON encoded.
+func flattenParamValue(value *tektonv1beta1.ParamValue) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	var encoded []byte
+	var err error
+	switch value.Type {
+	case tektonv1beta1.ParamTypeArray:
+		encoded, err = json.Marshal(value.ArrayVal)
+	case tektonv1beta1.ParamTypeObject:
+		encoded, err = json.Marshal(value.ObjectVal)
+	default:
+		return value.StringVal, nil
+	}
+	return string(encoded), err
+}
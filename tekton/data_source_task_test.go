@@ -0,0 +1,76 @@
+package tekton
+
+import (
+	"reflect"
+	"testing"
+
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+)
+
+func TestFlattenParamValue(t *testing.T) {
+	tests := []struct {
+		name  string
+		value *tektonv1beta1.ParamValue
+		want  string
+	}{
+		{name: "nil", value: nil, want: ""},
+		{name: "string", value: tektonv1beta1.NewStructuredValues("main"), want: "main"},
+		{name: "empty string", value: tektonv1beta1.NewStructuredValues(""), want: ""},
+		{name: "array", value: tektonv1beta1.NewStructuredValues("a", "b"), want: `["a","b"]`},
+		{name: "empty array", value: &tektonv1beta1.ParamValue{Type: tektonv1beta1.ParamTypeArray, ArrayVal: []string{}}, want: `[]`},
+		{name: "object", value: tektonv1beta1.NewObject(map[string]string{"url": "https://example.com", "ref": "main"}), want: `{"ref":"main","url":"https://example.com"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := flattenParamValue(tt.value)
+			if err != nil {
+				t.Fatalf("flattenParamValue() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("flattenParamValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFlattenParamSpecs(t *testing.T) {
+	specs := tektonv1beta1.ParamSpecs{
+		{Name: "revision", Type: tektonv1beta1.ParamTypeString, Description: "The revision to build."},
+		{Name: "mode", Type: tektonv1beta1.ParamTypeString, Default: tektonv1beta1.NewStructuredValues(""), Enum: []string{"", "debug"}},
+		{Name: "flags", Type: tektonv1beta1.ParamTypeArray, Default: &tektonv1beta1.ParamValue{Type: tektonv1beta1.ParamTypeArray, ArrayVal: []string{"-v"}}},
+	}
+	want := []interface{}{
+		map[string]interface{}{
+			"name":        "revision",
+			"type":        "string",
+			"description": "The revision to build.",
+			"default":     "",
+			"has_default": false,
+			"enum":        []string(nil),
+		},
+		map[string]interface{}{
+			"name":        "mode",
+			"type":        "string",
+			"description": "",
+			"default":     "",
+			"has_default": true,
+			"enum":        []string{"", "debug"},
+		},
+		map[string]interface{}{
+			"name":        "flags",
+			"type":        "array",
+			"description": "",
+			"default":     `["-v"]`,
+			"has_default": true,
+			"enum":        []string(nil),
+		},
+	}
+
+	got, err := flattenParamSpecs(specs)
+	if err != nil {
+		t.Fatalf("flattenParamSpecs() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenParamSpecs() = %v, want %v", got, want)
+	}
+}
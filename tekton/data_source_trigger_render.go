@@ -0,0 +1,286 @@
+package tekton
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	"github.com/tektoncd/triggers/pkg/interceptors/cel"
+	"github.com/tektoncd/triggers/pkg/template"
+	"google.golang.org/grpc/codes"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// renderTriggerID is the trigger ID interceptors see while rendering, of the form namespaces/$ns/triggers/$name.
+const renderTriggerID = "namespaces/default/triggers/render"
+
+// dataSourceTektonTriggerRender evaluates TriggerBinding params, CEL interceptors and
+// TriggerTemplate resource templates against a sample event, without a cluster.
+func dataSourceTektonTriggerRender() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTektonTriggerRenderRead,
+
+		Schema: map[string]*schema.Schema{
+			"body": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The JSON body of the sample event.",
+			},
+			"headers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The HTTP headers of the sample event.",
+			},
+			"cel": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "CEL interceptors run against the event in order, before the bindings are evaluated.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"filter": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"overlays": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+									"expression": {
+										Type:     schema.TypeString,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"bindings": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The binding params, e.g. tekton_triggerbinding.example.bindings.",
+				Elem:        triggerBindingParamsSchema().Elem,
+			},
+			"params": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The TriggerTemplate params, e.g. tekton_triggertemplate.example.params.",
+				Elem:        triggerTemplateSpecSchema()["params"].Elem,
+			},
+			"resourcetemplates": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "The TriggerTemplate resource templates, e.g. tekton_triggertemplate.example.resourcetemplates.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"accepted": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether every CEL filter accepted the event. Nothing is rendered when false.",
+			},
+			"extensions": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The JSON extensions added by the CEL overlays.",
+			},
+			"resolved_params": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The value of each TriggerTemplate param.",
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The rendered resource templates as JSON documents. $(uid) is replaced with a UID derived from the inputs, so it only changes when they do.",
+			},
+		},
+	}
+}
+
+func dataSourceTektonTriggerRenderRead(d *schema.ResourceData, m interface{}) error {
+	body := []byte(d.Get("body").(string))
+	header := http.Header{}
+	for k, v := range d.Get("headers").(map[string]interface{}) {
+		header.Set(k, v.(string))
+	}
+
+	extensions, accepted, err := runCELInterceptors(d.Get("cel").([]interface{}), body, header)
+	if err != nil {
+		return err
+	}
+	extensionsJSON, err := json.Marshal(extensions)
+	if err != nil {
+		return fmt.Errorf("failed to encode extensions: %v", err)
+	}
+
+	d.SetId(renderTriggerID)
+	d.Set("accepted", accepted)
+	d.Set("extensions", string(extensionsJSON))
+	if !accepted {
+		d.Set("resolved_params", map[string]interface{}{})
+		d.Set("resources", []interface{}{})
+		return nil
+	}
+
+	triggerTemplate, err := getRenderTriggerTemplate(d.Get("params").([]interface{}), d.Get("resourcetemplates").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	var bindingParams []triggersv1beta1.Param
+	for _, tfBinding := range d.Get("bindings").([]interface{}) {
+		bindingData := tfBinding.(map[string]interface{})
+		bindingParams = append(bindingParams, triggersv1beta1.Param{
+			Name:  bindingData["name"].(string),
+			Value: bindingData["value"].(string),
+		})
+	}
+
+	resolvedTrigger := template.ResolvedTrigger{
+		TriggerTemplate: triggerTemplate,
+		BindingParams:   bindingParams,
+	}
+	params, err := template.ResolveParams(resolvedTrigger, body, header, extensions, template.NewTriggerContext("render"))
+	if err != nil {
+		return fmt.Errorf("failed to resolve trigger params: %v", err)
+	}
+
+	resolvedParams := map[string]interface{}{}
+	for _, param := range params {
+		resolvedParams[param.Name] = param.Value
+	}
+
+	// An EventListener gives $(uid) a new value for every event. Rendering substitutes a UID
+	// derived from the inputs before the library generates one, so every read is the same.
+	uid := renderUID(triggerTemplate, params)
+	for i := range triggerTemplate.Spec.ResourceTemplates {
+		resourceTemplate := &triggerTemplate.Spec.ResourceTemplates[i]
+		resourceTemplate.Raw = bytes.ReplaceAll(resourceTemplate.Raw, []byte("$(uid)"), []byte(uid))
+	}
+	uidParams := make([]triggersv1beta1.Param, 0, len(params))
+	for _, param := range params {
+		param.Value = strings.ReplaceAll(param.Value, "$(uid)", uid)
+		uidParams = append(uidParams, param)
+	}
+
+	var resources []interface{}
+	for _, resource := range template.ResolveResources(triggerTemplate, uidParams) {
+		resources = append(resources, string(resource))
+	}
+
+	d.Set("resolved_params", resolvedParams)
+	d.Set("resources", resources)
+
+	return nil
+}
+
+// renderUID returns a UID for $(uid) that is derived from the resolved params and the resource
+// templates, so that the same inputs always render the same resources.
+func renderUID(triggerTemplate *triggersv1beta1.TriggerTemplate, params []triggersv1beta1.Param) string {
+	sorted := append([]triggersv1beta1.Param(nil), params...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	hash := sha256.New()
+	for _, param := range sorted {
+		fmt.Fprintf(hash, "%q=%q\n", param.Name, param.Value)
+	}
+	for _, resourceTemplate := range triggerTemplate.Spec.ResourceTemplates {
+		hash.Write(resourceTemplate.Raw)
+		hash.Write([]byte{0})
+	}
+	return uuid.NewSHA1(uuid.Nil, hash.Sum(nil)).String()
+}
+
+// runCELInterceptors runs the CEL interceptors in order, merging their extensions like an
+// EventListener does. It stops and returns false as soon as a filter rejects the event.
+func runCELInterceptors(tfInterceptors []interface{}, body []byte, header http.Header) (map[string]interface{}, bool, error) {
+	extensions := map[string]interface{}{}
+	interceptor := cel.NewInterceptor(nil)
+
+	for i, tfInterceptor := range tfInterceptors {
+		interceptorData := tfInterceptor.(map[string]interface{})
+
+		var overlays []interface{}
+		for _, tfOverlay := range interceptorData["overlays"].([]interface{}) {
+			overlayData := tfOverlay.(map[string]interface{})
+			overlays = append(overlays, map[string]interface{}{
+				"key":        overlayData["key"],
+				"expression": overlayData["expression"],
+			})
+		}
+
+		response := interceptor.Process(context.Background(), &triggersv1beta1.InterceptorRequest{
+			Body:       string(body),
+			Header:     header,
+			Extensions: extensions,
+			InterceptorParams: map[string]interface{}{
+				"filter":   interceptorData["filter"],
+				"overlays": overlays,
+			},
+			Context: &triggersv1beta1.TriggerContext{
+				EventID:   "render",
+				TriggerID: renderTriggerID,
+			},
+		})
+		if !response.Continue {
+			if response.Status.Code == codes.FailedPrecondition {
+				return extensions, false, nil
+			}
+			return nil, false, fmt.Errorf("cel.%d: %s", i, response.Status.Message)
+		}
+
+		for k, v := range response.Extensions {
+			extensions[k] = v
+		}
+	}
+
+	return extensions, true, nil
+}
+
+// Helper function to convert Terraform TriggerTemplate attributes into a TriggerTemplate to render
+func getRenderTriggerTemplate(tfParams []interface{}, tfResourceTemplates []interface{}) (*triggersv1beta1.TriggerTemplate, error) {
+	triggerTemplate := &triggersv1beta1.TriggerTemplate{}
+
+	for _, tfParam := range tfParams {
+		paramData := tfParam.(map[string]interface{})
+		param := triggersv1beta1.ParamSpec{
+			Name:        paramData["name"].(string),
+			Description: paramData["description"].(string),
+		}
+		if v := paramData["default"].(string); v != "" {
+			param.Default = &v
+		}
+		triggerTemplate.Spec.Params = append(triggerTemplate.Spec.Params, param)
+	}
+
+	for i, tfTemplate := range tfResourceTemplates {
+		raw, err := yaml.YAMLToJSON([]byte(tfTemplate.(string)))
+		if err != nil {
+			return nil, fmt.Errorf("resourcetemplates.%d is not valid JSON or YAML: %v", i, err)
+		}
+		triggerTemplate.Spec.ResourceTemplates = append(triggerTemplate.Spec.ResourceTemplates, triggersv1beta1.TriggerResourceTemplate{
+			RawExtension: runtime.RawExtension{Raw: raw},
+		})
+	}
+
+	return triggerTemplate, nil
+}
@@ -0,0 +1,187 @@
+package tekton
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceTektonTriggerRenderRead(t *testing.T) {
+	pipelineRun := `apiVersion: tekton.dev/v1beta1
+kind: PipelineRun
+metadata:
+  generateName: build-
+spec:
+  params:
+    - name: revision
+      value: $(tt.params.revision)
+    - name: branch
+      value: $(tt.params.branch)`
+
+	tests := []struct {
+		name          string
+		config        map[string]interface{}
+		wantErr       string
+		wantAccepted  bool
+		wantParams    map[string]interface{}
+		wantResources []string
+	}{
+		{
+			name: "binds body, header and overlay values",
+			config: map[string]interface{}{
+				"body":    `{"action":"opened","head":{"sha":"0123456789abcdef"}}`,
+				"headers": map[string]interface{}{"X-GitHub-Event": "pull_request"},
+				"cel": []interface{}{map[string]interface{}{
+					"filter": "body.action == 'opened' && header.match('X-GitHub-Event', 'pull_request')",
+					"overlays": []interface{}{map[string]interface{}{
+						"key":        "short_sha",
+						"expression": "body.head.sha.truncate(7)",
+					}},
+				}},
+				"bindings": []interface{}{
+					map[string]interface{}{"name": "revision", "value": "$(extensions.short_sha)"},
+					map[string]interface{}{"name": "branch", "value": "$(header.X-GitHub-Event)"},
+				},
+				"params": []interface{}{
+					map[string]interface{}{"name": "revision"},
+					map[string]interface{}{"name": "branch"},
+				},
+				"resourcetemplates": []interface{}{pipelineRun},
+			},
+			wantAccepted:  true,
+			wantParams:    map[string]interface{}{"revision": "0123456", "branch": "pull_request"},
+			wantResources: []string{`"value":"0123456"`, `"value":"pull_request"`},
+		},
+		{
+			name: "uses param defaults",
+			config: map[string]interface{}{
+				"body": `{"head":{"sha":"abc"}}`,
+				"bindings": []interface{}{
+					map[string]interface{}{"name": "revision", "value": "$(body.head.sha)"},
+				},
+				"params": []interface{}{
+					map[string]interface{}{"name": "revision"},
+					map[string]interface{}{"name": "branch", "default": "main"},
+				},
+				"resourcetemplates": []interface{}{pipelineRun},
+			},
+			wantAccepted:  true,
+			wantParams:    map[string]interface{}{"revision": "abc", "branch": "main"},
+			wantResources: []string{`"value":"abc"`, `"value":"main"`},
+		},
+		{
+			name: "filter rejects the event",
+			config: map[string]interface{}{
+				"body": `{"action":"closed"}`,
+				"cel": []interface{}{map[string]interface{}{
+					"filter": "body.action == 'opened'",
+				}},
+				"resourcetemplates": []interface{}{pipelineRun},
+			},
+			wantAccepted: false,
+			wantParams:   map[string]interface{}{},
+		},
+		{
+			name: "invalid CEL expression",
+			config: map[string]interface{}{
+				"body": `{}`,
+				"cel": []interface{}{map[string]interface{}{
+					"filter": "body.action ==",
+				}},
+				"resourcetemplates": []interface{}{pipelineRun},
+			},
+			wantErr: "cel.0:",
+		},
+		{
+			name: "binding expression that does not resolve",
+			config: map[string]interface{}{
+				"body": `{}`,
+				"bindings": []interface{}{
+					map[string]interface{}{"name": "revision", "value": "$(body.head.sha)"},
+				},
+				"params": []interface{}{
+					map[string]interface{}{"name": "revision"},
+				},
+				"resourcetemplates": []interface{}{pipelineRun},
+			},
+			wantErr: "failed to resolve trigger params",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceTektonTriggerRender().Schema, tt.config)
+
+			err := dataSourceTektonTriggerRenderRead(d, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("dataSourceTektonTriggerRenderRead() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("dataSourceTektonTriggerRenderRead() error = %v", err)
+			}
+
+			if got := d.Get("accepted").(bool); got != tt.wantAccepted {
+				t.Errorf("accepted = %v, want %v", got, tt.wantAccepted)
+			}
+			if got := d.Get("resolved_params").(map[string]interface{}); !reflect.DeepEqual(got, tt.wantParams) {
+				t.Errorf("resolved_params = %v, want %v", got, tt.wantParams)
+			}
+
+			resources := d.Get("resources").([]interface{})
+			if len(tt.wantResources) == 0 && len(resources) != 0 {
+				t.Errorf("resources = %v, want none", resources)
+			}
+			for _, want := range tt.wantResources {
+				if len(resources) != 1 || !strings.Contains(resources[0].(string), want) {
+					t.Errorf("resources = %v, want one containing %s", resources, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDataSourceTektonTriggerRenderReadUID(t *testing.T) {
+	render := func(revision string) string {
+		t.Helper()
+		d := schema.TestResourceDataRaw(t, dataSourceTektonTriggerRender().Schema, map[string]interface{}{
+			"body":              `{}`,
+			"bindings":          []interface{}{map[string]interface{}{"name": "revision", "value": revision}},
+			"params":            []interface{}{map[string]interface{}{"name": "revision"}},
+			"resourcetemplates": []interface{}{`{"metadata":{"name":"build-$(uid)"},"spec":{"revision":"$(tt.params.revision)"}}`},
+		})
+		if err := dataSourceTektonTriggerRenderRead(d, nil); err != nil {
+			t.Fatalf("dataSourceTektonTriggerRenderRead() error = %v", err)
+		}
+		resources := d.Get("resources").([]interface{})
+		if len(resources) != 1 {
+			t.Fatalf("resources = %v, want one", resources)
+		}
+		var resource struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+		}
+		if err := json.Unmarshal([]byte(resources[0].(string)), &resource); err != nil {
+			t.Fatalf("invalid resource %s: %v", resources[0], err)
+		}
+		uid := strings.TrimPrefix(resource.Metadata.Name, "build-")
+		if _, err := uuid.Parse(uid); err != nil {
+			t.Fatalf("name = %q, want build- followed by a UID", resource.Metadata.Name)
+		}
+		return uid
+	}
+
+	first := render("main")
+	if again := render("main"); again != first {
+		t.Errorf("$(uid) = %s on the second read, want %s", again, first)
+	}
+	if other := render("dev"); other == first {
+		t.Errorf("$(uid) = %s for other params, want a different UID", other)
+	}
+}
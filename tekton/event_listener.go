@@ -2,13 +2,21 @@ package tekton
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
-	triggersclient "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	triggersv1beta1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
 )
 
 func resourceTektonEventListener() *schema.Resource {
@@ -18,57 +26,152 @@ func resourceTektonEventListener() *schema.Resource {
 		Update: resourceTektonEventListenerUpdate,
 		Delete: resourceTektonEventListenerDelete,
 
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: customdiff.Sequence(customizeEventListenerDiff, customizeLabelsDiff),
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"namespace": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "default",
+				ForceNew: true,
+			},
+			"service_account_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ServiceAccount the listener runs as. It needs permission to read the triggers and create the resources they template.",
+			},
+			"namespace_selector": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Serve Triggers from other namespaces as well as the listener's own.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"match_names": {
+							Type:        schema.TypeList,
+							Required:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Namespaces to serve Triggers from, or [\"*\"] for all namespaces.",
+						},
+					},
+				},
+			},
+			"label_selector": labelSelectorSchema("Only serve Triggers whose labels match."),
+			"resources": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"kubernetes_resource": {
+							Type:          schema.TypeList,
+							Optional:      true,
+							MaxItems:      1,
+							ConflictsWith: []string{"resources.0.custom_resource"},
+							Description:   "Overrides for the Deployment and Service created for the listener.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"replicas": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+									},
+									"service_type": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ValidateFunc: validation.StringInSlice([]string{
+											string(corev1.ServiceTypeClusterIP),
+											string(corev1.ServiceTypeNodePort),
+											string(corev1.ServiceTypeLoadBalancer),
+										}, false),
+									},
+									"service_port": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IsPortNumber,
+										Description:  "The port the listener's Service exposes.",
+									},
+									"pod_template": eventListenerPodTemplateSchema(),
+								},
+							},
+						},
+						"custom_resource": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ValidateFunc:     validateResourceTemplate,
+							DiffSuppressFunc: suppressEquivalentDocument,
+							Description:      "A JSON or YAML document, such as a Knative Service, to run the listener as instead of a Deployment.",
+						},
+					},
+				},
+			},
+			"address_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The in-cluster URL the listener receives events on.",
+			},
+			"service_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the Service created for the listener.",
 			},
 			"triggers": {
 				Type:     schema.TypeList,
 				Required: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
-						"trigger_template_name": {
+						"name": {
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
+						},
+						"trigger_ref": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The name of a standalone Trigger to use. Mutually exclusive with bindings, template and interceptors.",
+						},
+						"bindings":     triggerBindingsSchema(),
+						"template":     triggerTemplateSchema(),
+						"interceptors": triggerInterceptorsSchema(),
+						"trigger_template_name": {
+							Type:       schema.TypeString,
+							Optional:   true,
+							Deprecated: "Use template { ref = ... } instead.",
 						},
 						"trigger_binding_name": {
-							Type:     schema.TypeString,
-							Required: true,
+							Type:       schema.TypeString,
+							Optional:   true,
+							Deprecated: "Use bindings { ref = ... } instead.",
 						},
 					},
 				},
 			},
-		},
+		}, metadataSchema()),
 	}
 }
 
 func resourceTektonEventListenerCreate(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Get("name").(string)
 	namespace := d.Get("namespace").(string)
 
-	// triggers := getEventListenerTriggers(d.Get("triggers").([]interface{}))
+	eventListener, err := getEventListener(clients.Metadata, d, name, namespace)
+	if err != nil {
+		return err
+	}
 
-	eventListener := &tektonv1alpha1.EventListener{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: tektonv1alpha1.EventListenerSpec{
-			// Triggers: triggers,
-		},
+	if _, err := clients.TektonTriggersClient.TriggersV1beta1().EventListeners(namespace).Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "EventListener", namespace, name)
 	}
 
-	_, err := clients.TektonTriggersClient.TriggersV1alpha1().EventListeners(namespace).Create(context.Background(), eventListener, metav1.CreateOptions{})
+	patch, err := getApplyPatch(eventListener)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1beta1().EventListeners(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create Tekton EventListener: %v", err)
 	}
@@ -78,35 +181,76 @@ func resourceTektonEventListenerCreate(d *schema.ResourceData, m interface{}) er
 }
 
 func resourceTektonEventListenerRead(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
-	_, err := clients.TektonTriggersClient.TriggersV1alpha1().EventListeners(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	eventListener, err := clients.TektonTriggersClient.TriggersV1beta1().EventListeners(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
 		d.SetId("")
 		return nil
 	}
 
+	setObjectMeta(clients, d, eventListener.ObjectMeta)
+
+	// The spec is flattened with the v1alpha1 types it is built with; see getEventListener.
+	var spec tektonv1alpha1.EventListenerSpec
+	encoded, err := json.Marshal(eventListener.Spec)
+	if err == nil {
+		err = json.Unmarshal(encoded, &spec)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to convert the EventListener spec from v1beta1: %v", err)
+	}
+	var servicePort *int32
+	if eventListener.Spec.Resources.KubernetesResource != nil {
+		servicePort = eventListener.Spec.Resources.KubernetesResource.ServicePort
+	}
+
+	meta := eventListener.ObjectMeta
+	setManagedField(clients, d, meta, "service_account_name", spec.ServiceAccountName, "spec", "serviceAccountName")
+	setManagedField(clients, d, meta, "triggers", flattenEventListenerTriggers(spec.Triggers, d.Get("triggers").([]interface{})), "spec", "triggers")
+	setManagedField(clients, d, meta, "namespace_selector", flattenNamespaceSelector(spec.NamespaceSelector), "spec", "namespaceSelector")
+	setManagedField(clients, d, meta, "label_selector", flattenLabelSelector(spec.LabelSelector), "spec", "labelSelector")
+	setManagedField(clients, d, meta, "resources", flattenEventListenerResources(spec.Resources, servicePort), "spec", "resources")
+
+	addressURL := ""
+	if eventListener.Status.Address != nil && eventListener.Status.Address.URL != nil {
+		addressURL = eventListener.Status.Address.URL.String()
+	}
+	d.Set("address_url", addressURL)
+	d.Set("service_name", eventListener.Status.Configuration.GeneratedResourceName)
+
 	return nil
 }
 
 func resourceTektonEventListenerUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	eventListener, err := getEventListener(clients.Metadata, d, name, namespace)
+	if err != nil {
+		return err
+	}
+
+	patch, err := getApplyPatch(eventListener)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1beta1().EventListeners(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton EventListener: %v", err)
+	}
+
 	return resourceTektonEventListenerRead(d, m)
 }
 
 func resourceTektonEventListenerDelete(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
-	err := clients.TektonTriggersClient.TriggersV1alpha1().EventListeners(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	err := clients.TektonTriggersClient.TriggersV1beta1().EventListeners(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
 	if err != nil {
 		return fmt.Errorf("failed to delete Tekton EventListener: %v", err)
 	}
@@ -115,21 +259,384 @@ func resourceTektonEventListenerDelete(d *schema.ResourceData, m interface{}) er
 	return nil
 }
 
-// func getEventListenerTriggers(tfTriggers []interface{}) []tektonv1alpha1.EventListenerTrigger {
-// 	var triggers []tektonv1alpha1.EventListenerTrigger
-// 	for _, tfTrigger := range tfTriggers {
-// 		triggerData := tfTrigger.(map[string]interface{})
-// 		trigger := tektonv1alpha1.EventListenerTrigger{
-// 			Template: tektonv1alpha1.EventListenerTemplate{
-// 				Name: triggerData["trigger_template_name"].(string),
-// 			},
-// 			Bindings: []*tektonv1alpha1.EventListenerBinding{
-// 				{
-// 					Name: triggerData["trigger_binding_name"].(string),
-// 				},
-// 			},
-// 		}
-// 		triggers = append(triggers, trigger)
-// 	}
-// 	return triggers
-// }
+// getEventListener builds an EventListener from the resource configuration. It is applied through
+// the triggers v1beta1 API, the only version with a service port, so that the whole listener is
+// applied and owned in one request. The spec is built with the v1alpha1 types shared with
+// tekton_trigger and converted through JSON, which both versions encode alike.
+func getEventListener(config metadataConfig, d *schema.ResourceData, name, namespace string) (*triggersv1beta1.EventListener, error) {
+	triggers, err := getEventListenerTriggers(d.Get("triggers").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	resources, err := getEventListenerResources(d.Get("resources").([]interface{}), d.GetRawConfig().GetAttr("resources"))
+	if err != nil {
+		return nil, err
+	}
+
+	spec := tektonv1alpha1.EventListenerSpec{
+		ServiceAccountName: d.Get("service_account_name").(string),
+		Triggers:           triggers,
+		NamespaceSelector:  getNamespaceSelector(d.Get("namespace_selector").([]interface{})),
+		LabelSelector:      getLabelSelector(d.Get("label_selector").([]interface{})),
+		Resources:          resources,
+	}
+	eventListener := &triggersv1beta1.EventListener{
+		ObjectMeta: getObjectMeta(config, d, name, namespace),
+	}
+	encoded, err := json.Marshal(spec)
+	if err == nil {
+		err = json.Unmarshal(encoded, &eventListener.Spec)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert the EventListener spec to v1beta1: %v", err)
+	}
+
+	if port := getEventListenerServicePort(d.Get("resources").([]interface{})); port != 0 && eventListener.Spec.Resources.KubernetesResource != nil {
+		servicePort := int32(port)
+		eventListener.Spec.Resources.KubernetesResource.ServicePort = &servicePort
+	}
+
+	return eventListener, nil
+}
+
+// customizeEventListenerDiff checks at plan time that every trigger's bindings supply the
+// params of its template that have no default.
+func customizeEventListenerDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	clients, ok := m.(providerClients)
+	if !ok || !rawPlanKnown(d, "namespace", "triggers") {
+		return nil
+	}
+
+	triggers, err := getEventListenerTriggers(d.Get("triggers").([]interface{}))
+	if err != nil {
+		return err
+	}
+	return checkEventListenerTriggersBound(clients, d.Get("namespace").(string), triggers)
+}
+
+// checkEventListenerTriggersBound runs checkTriggerParamsBound for every trigger.
+func checkEventListenerTriggersBound(clients providerClients, namespace string, triggers []tektonv1alpha1.EventListenerTrigger) error {
+	for i, trigger := range triggers {
+		if err := checkTriggerParamsBound(clients, namespace, trigger.Bindings, trigger.Template); err != nil {
+			return fmt.Errorf("triggers.%d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Helper function to convert Terraform triggers into Tekton EventListener triggers
+func getEventListenerTriggers(tfTriggers []interface{}) ([]tektonv1alpha1.EventListenerTrigger, error) {
+	var triggers []tektonv1alpha1.EventListenerTrigger
+	for i, tfTrigger := range tfTriggers {
+		triggerData := tfTrigger.(map[string]interface{})
+		trigger := tektonv1alpha1.EventListenerTrigger{
+			Name:       triggerData["name"].(string),
+			TriggerRef: triggerData["trigger_ref"].(string),
+		}
+
+		bindings, err := getTriggerSpecBindings(triggerData["bindings"].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("triggers.%d.%v", i, err)
+		}
+		if v := triggerData["trigger_binding_name"].(string); v != "" {
+			bindings = append(bindings, &tektonv1alpha1.TriggerSpecBinding{Ref: v})
+		}
+		trigger.Bindings = bindings
+
+		if tfTemplate := triggerData["template"].([]interface{}); len(tfTemplate) > 0 {
+			if trigger.Template, err = getTriggerSpecTemplate(tfTemplate); err != nil {
+				return nil, fmt.Errorf("triggers.%d.%v", i, err)
+			}
+		} else if v := triggerData["trigger_template_name"].(string); v != "" {
+			trigger.Template = &tektonv1alpha1.TriggerSpecTemplate{Ref: &v}
+		}
+
+		if trigger.Interceptors, err = getTriggerInterceptors(triggerData["interceptors"].([]interface{})); err != nil {
+			return nil, fmt.Errorf("triggers.%d.%v", i, err)
+		}
+
+		if trigger.TriggerRef != "" && (trigger.Template != nil || len(trigger.Bindings) > 0 || len(trigger.Interceptors) > 0) {
+			return nil, fmt.Errorf("triggers.%d: trigger_ref is mutually exclusive with bindings, template and interceptors", i)
+		}
+		if trigger.TriggerRef == "" && trigger.Template == nil {
+			return nil, fmt.Errorf("triggers.%d: one of trigger_ref or template must be set", i)
+		}
+
+		triggers = append(triggers, trigger)
+	}
+	return triggers, nil
+}
+
+// flattenEventListenerTriggers converts EventListener triggers into triggers blocks. A template
+// or last binding that the prior block set through the deprecated trigger_template_name or
+// trigger_binding_name is flattened back into it.
+func flattenEventListenerTriggers(triggers []tektonv1alpha1.EventListenerTrigger, tfPrior []interface{}) []interface{} {
+	var tfTriggers []interface{}
+	for i, trigger := range triggers {
+		priorData := map[string]interface{}{}
+		if i < len(tfPrior) && tfPrior[i] != nil {
+			priorData = tfPrior[i].(map[string]interface{})
+		}
+		tfTrigger := map[string]interface{}{
+			"name":        trigger.Name,
+			"trigger_ref": trigger.TriggerRef,
+		}
+
+		bindings := trigger.Bindings
+		if v, _ := priorData["trigger_binding_name"].(string); v != "" && len(bindings) > 0 {
+			if last := bindings[len(bindings)-1]; last.Ref == v && last.Kind != tektonv1alpha1.ClusterTriggerBindingKind {
+				tfTrigger["trigger_binding_name"] = v
+				bindings = bindings[:len(bindings)-1]
+			}
+		}
+		tfTrigger["bindings"] = flattenTriggerSpecBindings(bindings)
+
+		priorTemplate, _ := priorData["template"].([]interface{})
+		if v, _ := priorData["trigger_template_name"].(string); v != "" && len(priorTemplate) == 0 && trigger.Template != nil && trigger.Template.Ref != nil && *trigger.Template.Ref == v {
+			tfTrigger["trigger_template_name"] = v
+		} else {
+			tfTrigger["template"] = flattenTriggerSpecTemplate(trigger.Template)
+		}
+
+		priorInterceptors, _ := priorData["interceptors"].([]interface{})
+		tfTrigger["interceptors"] = flattenTriggerInterceptors(trigger.Interceptors, priorInterceptors)
+
+		tfTriggers = append(tfTriggers, tfTrigger)
+	}
+	return tfTriggers
+}
+
+// eventListenerPodTemplateSchema defines the pod fields an EventListener allows to be overridden.
+func eventListenerPodTemplateSchema() *schema.Schema {
+	podTemplate := podTemplateSchema().Elem.(*schema.Resource).Schema
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"service_account_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"node_selector":     podTemplate["node_selector"],
+				"tolerations":       podTemplate["tolerations"],
+				"compute_resources": computeResourcesSchema(),
+				"env":               envSchema("Environment variables of the listener container."),
+			},
+		},
+	}
+}
+
+// labelSelectorSchema defines a Kubernetes label selector.
+func labelSelectorSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"match_labels": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"match_expressions": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"key": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"operator": {
+								Type:     schema.TypeString,
+								Required: true,
+								ValidateFunc: validation.StringInSlice([]string{
+									string(metav1.LabelSelectorOpIn),
+									string(metav1.LabelSelectorOpNotIn),
+									string(metav1.LabelSelectorOpExists),
+									string(metav1.LabelSelectorOpDoesNotExist),
+								}, false),
+							},
+							"values": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func getNamespaceSelector(tfSelector []interface{}) tektonv1alpha1.NamespaceSelector {
+	if len(tfSelector) == 0 || tfSelector[0] == nil {
+		return tektonv1alpha1.NamespaceSelector{}
+	}
+	selectorData := tfSelector[0].(map[string]interface{})
+	return tektonv1alpha1.NamespaceSelector{
+		MatchNames: toStringSlice(selectorData["match_names"].([]interface{})),
+	}
+}
+
+// Helper function to convert a Terraform label_selector block into a Kubernetes label selector
+func getLabelSelector(tfSelector []interface{}) *metav1.LabelSelector {
+	if len(tfSelector) == 0 || tfSelector[0] == nil {
+		return nil
+	}
+	selectorData := tfSelector[0].(map[string]interface{})
+	selector := &metav1.LabelSelector{
+		MatchLabels: toStringMap(selectorData["match_labels"].(map[string]interface{})),
+	}
+	for _, tfExpression := range selectorData["match_expressions"].([]interface{}) {
+		expressionData := tfExpression.(map[string]interface{})
+		selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+			Key:      expressionData["key"].(string),
+			Operator: metav1.LabelSelectorOperator(expressionData["operator"].(string)),
+			Values:   toStringSlice(expressionData["values"].([]interface{})),
+		})
+	}
+	return selector
+}
+
+func flattenNamespaceSelector(selector tektonv1alpha1.NamespaceSelector) []interface{} {
+	if len(selector.MatchNames) == 0 {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"match_names": selector.MatchNames,
+	}}
+}
+
+func flattenLabelSelector(selector *metav1.LabelSelector) []interface{} {
+	if selector == nil {
+		return nil
+	}
+	var expressions []interface{}
+	for _, expression := range selector.MatchExpressions {
+		expressions = append(expressions, map[string]interface{}{
+			"key":      expression.Key,
+			"operator": string(expression.Operator),
+			"values":   expression.Values,
+		})
+	}
+	return []interface{}{map[string]interface{}{
+		"match_labels":      selector.MatchLabels,
+		"match_expressions": expressions,
+	}}
+}
+
+// Helper function to convert a Terraform resources block into EventListener resources. The raw
+// config of the block tells replicas = 0 apart from an unset replicas.
+func getEventListenerResources(tfResources []interface{}, rawResources cty.Value) (tektonv1alpha1.Resources, error) {
+	var resources tektonv1alpha1.Resources
+	if len(tfResources) == 0 || tfResources[0] == nil {
+		return resources, nil
+	}
+	resourcesData := tfResources[0].(map[string]interface{})
+
+	if v := resourcesData["custom_resource"].(string); v != "" {
+		raw, err := yaml.YAMLToJSON([]byte(v))
+		if err != nil {
+			return resources, fmt.Errorf("resources.0.custom_resource is not valid JSON or YAML: %v", err)
+		}
+		resources.CustomResource = &tektonv1alpha1.CustomResource{
+			RawExtension: runtime.RawExtension{Raw: raw},
+		}
+	}
+
+	tfKubernetesResource := resourcesData["kubernetes_resource"].([]interface{})
+	if len(tfKubernetesResource) == 0 || tfKubernetesResource[0] == nil {
+		return resources, nil
+	}
+	kubernetesData := tfKubernetesResource[0].(map[string]interface{})
+	kubernetesResource := &tektonv1alpha1.KubernetesResource{
+		ServiceType: corev1.ServiceType(kubernetesData["service_type"].(string)),
+	}
+	if v := kubernetesData["replicas"].(int); v > 0 || rawConfigSet(rawResources, 0, "kubernetes_resource", 0, "replicas") {
+		replicas := int32(v)
+		kubernetesResource.Replicas = &replicas
+	}
+
+	if tfPodTemplate := kubernetesData["pod_template"].([]interface{}); len(tfPodTemplate) > 0 && tfPodTemplate[0] != nil {
+		templateData := tfPodTemplate[0].(map[string]interface{})
+		podSpec := &kubernetesResource.Template.Spec
+		podSpec.ServiceAccountName = templateData["service_account_name"].(string)
+		podSpec.NodeSelector = toStringMap(templateData["node_selector"].(map[string]interface{}))
+		podSpec.Tolerations = getTolerations(templateData["tolerations"].([]interface{}), rawConfigAt(rawResources, 0, "kubernetes_resource", 0, "pod_template", 0, "tolerations"))
+
+		computeResources, err := getComputeResources(templateData["compute_resources"].([]interface{}))
+		if err != nil {
+			return resources, fmt.Errorf("resources.0.kubernetes_resource.0.pod_template.0.compute_resources: %v", err)
+		}
+		env := getEnvVars(templateData["env"].([]interface{}))
+		if computeResources != nil || len(env) > 0 {
+			// The listener Deployment has a single container; only its resources and env can be overridden.
+			container := corev1.Container{Env: env}
+			if computeResources != nil {
+				container.Resources = *computeResources
+			}
+			podSpec.Containers = []corev1.Container{container}
+		}
+	}
+
+	resources.KubernetesResource = kubernetesResource
+	return resources, nil
+}
+
+// getEventListenerServicePort returns the service_port of a Terraform resources block, or 0.
+func getEventListenerServicePort(tfResources []interface{}) int {
+	if len(tfResources) == 0 || tfResources[0] == nil {
+		return 0
+	}
+	tfKubernetesResource := tfResources[0].(map[string]interface{})["kubernetes_resource"].([]interface{})
+	if len(tfKubernetesResource) == 0 || tfKubernetesResource[0] == nil {
+		return 0
+	}
+	return tfKubernetesResource[0].(map[string]interface{})["service_port"].(int)
+}
+
+// flattenEventListenerResources converts EventListener resources into a resources block. The
+// service port only exists in the triggers v1beta1 API and is passed separately.
+func flattenEventListenerResources(resources tektonv1alpha1.Resources, servicePort *int32) []interface{} {
+	tfResources := map[string]interface{}{}
+	if resources.CustomResource != nil {
+		tfResources["custom_resource"] = string(resources.CustomResource.Raw)
+	}
+	if kubernetesResource := resources.KubernetesResource; kubernetesResource != nil {
+		tfKubernetesResource := map[string]interface{}{
+			"service_type": string(kubernetesResource.ServiceType),
+		}
+		if kubernetesResource.Replicas != nil {
+			tfKubernetesResource["replicas"] = int(*kubernetesResource.Replicas)
+		}
+		if servicePort != nil {
+			tfKubernetesResource["service_port"] = int(*servicePort)
+		}
+
+		podSpec := kubernetesResource.Template.Spec
+		tfPodTemplate := map[string]interface{}{
+			"service_account_name": podSpec.ServiceAccountName,
+			"node_selector":        podSpec.NodeSelector,
+			"tolerations":          flattenTolerations(podSpec.Tolerations),
+		}
+		if len(podSpec.Containers) > 0 {
+			tfPodTemplate["compute_resources"] = flattenComputeResources(podSpec.Containers[0].Resources)
+			tfPodTemplate["env"] = flattenEnvVars(podSpec.Containers[0].Env)
+		}
+		if podSpec.ServiceAccountName != "" || len(podSpec.NodeSelector) > 0 || len(podSpec.Tolerations) > 0 || len(podSpec.Containers) > 0 {
+			tfKubernetesResource["pod_template"] = []interface{}{tfPodTemplate}
+		}
+
+		tfResources["kubernetes_resource"] = []interface{}{tfKubernetesResource}
+	}
+	if len(tfResources) == 0 {
+		return nil
+	}
+	return []interface{}{tfResources}
+}
@@ -0,0 +1,105 @@
+package tekton
+
+import (
+	"testing"
+
+	triggersfake "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
+)
+
+func TestGetEventListener(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []interface{}
+		wantPort  int32
+	}{
+		{name: "no resources"},
+		{
+			name: "service port",
+			resources: []interface{}{map[string]interface{}{
+				"kubernetes_resource": []interface{}{map[string]interface{}{"service_type": "NodePort", "service_port": 8080}},
+			}},
+			wantPort: 8080,
+		},
+		{
+			name: "no service port",
+			resources: []interface{}{map[string]interface{}{
+				"kubernetes_resource": []interface{}{map[string]interface{}{"service_type": "NodePort"}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testResourceData(t, resourceTektonEventListener(), map[string]interface{}{
+				"name":                 "listener",
+				"namespace":            "ci",
+				"service_account_name": "listener",
+				"triggers":             []interface{}{map[string]interface{}{"trigger_ref": "push"}},
+				"resources":            tt.resources,
+			})
+
+			eventListener, err := getEventListener(metadataConfig{}, d, "listener", "ci")
+			if err != nil {
+				t.Fatalf("getEventListener() error = %v", err)
+			}
+			if eventListener.Spec.ServiceAccountName != "listener" {
+				t.Errorf("serviceAccountName = %q, want %q", eventListener.Spec.ServiceAccountName, "listener")
+			}
+			if len(eventListener.Spec.Triggers) != 1 || eventListener.Spec.Triggers[0].TriggerRef != "push" {
+				t.Errorf("triggers = %+v, want a single reference to push", eventListener.Spec.Triggers)
+			}
+
+			var gotPort int32
+			if kubernetesResource := eventListener.Spec.Resources.KubernetesResource; kubernetesResource != nil {
+				if kubernetesResource.ServiceType != "NodePort" {
+					t.Errorf("serviceType = %q, want NodePort", kubernetesResource.ServiceType)
+				}
+				if kubernetesResource.ServicePort != nil {
+					gotPort = *kubernetesResource.ServicePort
+				}
+			}
+			if gotPort != tt.wantPort {
+				t.Errorf("servicePort = %d, want %d", gotPort, tt.wantPort)
+			}
+		})
+	}
+}
+
+func TestResourceTektonEventListenerRead(t *testing.T) {
+	d := testResourceData(t, resourceTektonEventListener(), map[string]interface{}{
+		"name":                 "listener",
+		"namespace":            "ci",
+		"service_account_name": "listener",
+		"triggers": []interface{}{
+			map[string]interface{}{"trigger_ref": "push"},
+			map[string]interface{}{
+				"name":     "pull-request",
+				"bindings": []interface{}{map[string]interface{}{"ref": "pull-request"}},
+				"template": []interface{}{map[string]interface{}{"ref": "build"}},
+				"interceptors": []interface{}{map[string]interface{}{"cel": []interface{}{map[string]interface{}{
+					"filter": "body.action == 'opened'",
+				}}}},
+			},
+		},
+		"namespace_selector": []interface{}{map[string]interface{}{"match_names": []interface{}{"ci", "apps"}}},
+		"label_selector":     []interface{}{map[string]interface{}{"match_labels": map[string]interface{}{"team": "ci"}}},
+		"resources": []interface{}{map[string]interface{}{
+			"kubernetes_resource": []interface{}{map[string]interface{}{
+				"replicas":     2,
+				"service_type": "NodePort",
+				"service_port": 8080,
+			}},
+		}},
+	})
+	eventListener, err := getEventListener(metadataConfig{}, d, "listener", "ci")
+	if err != nil {
+		t.Fatalf("getEventListener() error = %v", err)
+	}
+	d.SetId("listener")
+
+	clients := providerClients{
+		TektonTriggersClient: triggersfake.NewSimpleClientset(eventListener),
+		FieldManager:         "terraform",
+	}
+	testReadKeepsConfig(t, d, resourceTektonEventListenerRead, clients,
+		"service_account_name", "triggers", "namespace_selector", "label_selector", "resources")
+}
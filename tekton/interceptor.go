@@ -0,0 +1,273 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+)
+
+func resourceTektonInterceptor() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTektonInterceptorCreate,
+		Read:   resourceTektonInterceptorRead,
+		Update: resourceTektonInterceptorUpdate,
+		Delete: resourceTektonInterceptorDelete,
+
+		CustomizeDiff: customizeLabelsDiff,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+				ForceNew: true,
+			},
+			"client_config": interceptorClientConfigSchema(),
+			"address_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL the interceptor is reached at.",
+			},
+		}, metadataSchema()),
+	}
+}
+
+func resourceTektonInterceptorCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+	namespace := d.Get("namespace").(string)
+
+	clientConfig, err := getInterceptorClientConfig(d.Get("client_config").([]interface{}), namespace)
+	if err != nil {
+		return err
+	}
+
+	interceptor := &tektonv1alpha1.Interceptor{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec: tektonv1alpha1.InterceptorSpec{
+			ClientConfig: clientConfig,
+		},
+	}
+
+	if _, err := clients.TektonTriggersClient.TriggersV1alpha1().Interceptors(namespace).Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "Interceptor", namespace, name)
+	}
+
+	patch, err := getApplyPatch(interceptor)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().Interceptors(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create Tekton Interceptor: %v", err)
+	}
+
+	d.SetId(name)
+	return resourceTektonInterceptorRead(d, m)
+}
+
+func resourceTektonInterceptorRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	interceptor, err := clients.TektonTriggersClient.TriggersV1alpha1().Interceptors(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	setObjectMeta(clients, d, interceptor.ObjectMeta)
+
+	clientConfig := flattenInterceptorClientConfig(interceptor.Spec.ClientConfig, namespace, d.Get("client_config").([]interface{}))
+	setManagedField(clients, d, interceptor.ObjectMeta, "client_config", clientConfig, "spec", "clientConfig")
+
+	d.Set("address_url", getAddressURL(interceptor.Status.AddressStatus))
+
+	return nil
+}
+
+func resourceTektonInterceptorUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	clientConfig, err := getInterceptorClientConfig(d.Get("client_config").([]interface{}), namespace)
+	if err != nil {
+		return err
+	}
+
+	interceptor := &tektonv1alpha1.Interceptor{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec: tektonv1alpha1.InterceptorSpec{
+			ClientConfig: clientConfig,
+		},
+	}
+
+	patch, err := getApplyPatch(interceptor)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().Interceptors(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton Interceptor: %v", err)
+	}
+
+	return resourceTektonInterceptorRead(d, m)
+}
+
+func resourceTektonInterceptorDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	err := clients.TektonTriggersClient.TriggersV1alpha1().Interceptors(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete Tekton Interceptor: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// interceptorClientConfigSchema defines how the EventListener reaches an Interceptor or
+// ClusterInterceptor: a fully formed URL or a reference to the Service it runs behind.
+func interceptorClientConfigSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"url": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ExactlyOneOf: []string{"client_config.0.url", "client_config.0.service"},
+					ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+				},
+				"service": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"namespace": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The namespace of the Service. Defaults to the Interceptor's namespace; required for a ClusterInterceptor.",
+							},
+							"path": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"port": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								ValidateFunc: validation.IsPortNumber,
+								Description:  "The Service port. Defaults to 8443 with a ca_bundle and 80 without.",
+							},
+						},
+					},
+				},
+				"ca_bundle": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "PEM encoded CA bundle used to verify the interceptor's serving certificate.",
+				},
+			},
+		},
+	}
+}
+
+// Helper function to convert a Terraform client_config block into an interceptor client config
+func getInterceptorClientConfig(tfClientConfig []interface{}, defaultNamespace string) (tektonv1alpha1.ClientConfig, error) {
+	var clientConfig tektonv1alpha1.ClientConfig
+	if len(tfClientConfig) == 0 || tfClientConfig[0] == nil {
+		return clientConfig, nil
+	}
+	configData := tfClientConfig[0].(map[string]interface{})
+
+	if v := configData["ca_bundle"].(string); v != "" {
+		clientConfig.CaBundle = []byte(v)
+	}
+
+	if v := configData["url"].(string); v != "" {
+		url, err := apis.ParseURL(v)
+		if err != nil {
+			return clientConfig, fmt.Errorf("client_config.0.url is not a valid URL: %v", err)
+		}
+		clientConfig.URL = url
+	}
+
+	if v := configData["service"].([]interface{}); len(v) > 0 && v[0] != nil {
+		serviceData := v[0].(map[string]interface{})
+		service := &tektonv1alpha1.ServiceReference{
+			Name:      serviceData["name"].(string),
+			Namespace: serviceData["namespace"].(string),
+			Path:      serviceData["path"].(string),
+		}
+		if service.Namespace == "" {
+			service.Namespace = defaultNamespace
+		}
+		if service.Namespace == "" {
+			return clientConfig, fmt.Errorf("client_config.0.service.0.namespace must be set")
+		}
+		if port := int32(serviceData["port"].(int)); port != 0 {
+			service.Port = &port
+		}
+		clientConfig.Service = service
+	}
+
+	return clientConfig, nil
+}
+
+// flattenInterceptorClientConfig converts an interceptor client config into a client_config
+// block. A service namespace equal to defaultNamespace is left out if the prior block left it
+// out, as getInterceptorClientConfig fills it in.
+func flattenInterceptorClientConfig(clientConfig tektonv1alpha1.ClientConfig, defaultNamespace string, tfPrior []interface{}) []interface{} {
+	tfClientConfig := map[string]interface{}{
+		"ca_bundle": string(clientConfig.CaBundle),
+	}
+	if clientConfig.URL != nil {
+		tfClientConfig["url"] = clientConfig.URL.String()
+	}
+	if service := clientConfig.Service; service != nil {
+		namespace := service.Namespace
+		if namespace == defaultNamespace && getPriorString(tfPrior, "service", "namespace") == "" {
+			namespace = ""
+		}
+		tfService := map[string]interface{}{
+			"name":      service.Name,
+			"namespace": namespace,
+			"path":      service.Path,
+		}
+		if service.Port != nil {
+			tfService["port"] = int(*service.Port)
+		}
+		tfClientConfig["service"] = []interface{}{tfService}
+	}
+	return []interface{}{tfClientConfig}
+}
+
+// getAddressURL returns the URL of an addressable status, or "" if it has none yet.
+func getAddressURL(status duckv1.AddressStatus) string {
+	if status.Address == nil || status.Address.URL == nil {
+		return ""
+	}
+	return status.Address.URL.String()
+}
@@ -0,0 +1,105 @@
+package tekton
+
+import (
+	"testing"
+
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersfake "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetInterceptorClientConfig(t *testing.T) {
+	service := func(namespace string) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"url":       "",
+			"ca_bundle": "",
+			"service": []interface{}{map[string]interface{}{
+				"name":      "interceptor",
+				"namespace": namespace,
+				"path":      "",
+				"port":      0,
+			}},
+		}}
+	}
+
+	tests := []struct {
+		name             string
+		clientConfig     []interface{}
+		defaultNamespace string
+		wantNamespace    string
+		wantErr          string
+	}{
+		{name: "namespace set", clientConfig: service("triggers"), defaultNamespace: "ci", wantNamespace: "triggers"},
+		{name: "namespace defaulted", clientConfig: service(""), defaultNamespace: "ci", wantNamespace: "ci"},
+		{name: "cluster-scoped with namespace", clientConfig: service("triggers"), wantNamespace: "triggers"},
+		{name: "cluster-scoped without namespace", clientConfig: service(""), wantErr: "client_config.0.service.0.namespace must be set"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientConfig, err := getInterceptorClientConfig(tt.clientConfig, tt.defaultNamespace)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("getInterceptorClientConfig() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getInterceptorClientConfig() error = %v", err)
+			}
+			if clientConfig.Service == nil || clientConfig.Service.Namespace != tt.wantNamespace {
+				t.Errorf("service = %+v, want namespace %q", clientConfig.Service, tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func TestResourceTektonInterceptorRead(t *testing.T) {
+	tests := []struct {
+		name         string
+		clientConfig map[string]interface{}
+	}{
+		{
+			name:         "url",
+			clientConfig: map[string]interface{}{"url": "https://slack.ci.svc:8443/filter", "ca_bundle": "-----BEGIN CERTIFICATE-----"},
+		},
+		{
+			name: "service in the interceptor's namespace",
+			clientConfig: map[string]interface{}{"service": []interface{}{map[string]interface{}{
+				"name": "slack",
+				"port": 8443,
+			}}},
+		},
+		{
+			name: "service in another namespace",
+			clientConfig: map[string]interface{}{"service": []interface{}{map[string]interface{}{
+				"name":      "slack",
+				"namespace": "interceptors",
+				"path":      "/filter",
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := testResourceData(t, resourceTektonInterceptor(), map[string]interface{}{
+				"name":          "slack",
+				"namespace":     "ci",
+				"client_config": []interface{}{tt.clientConfig},
+			})
+			clientConfig, err := getInterceptorClientConfig(d.Get("client_config").([]interface{}), "ci")
+			if err != nil {
+				t.Fatalf("getInterceptorClientConfig() error = %v", err)
+			}
+			d.SetId("slack")
+
+			clients := providerClients{
+				TektonTriggersClient: triggersfake.NewSimpleClientset(&tektonv1alpha1.Interceptor{
+					ObjectMeta: metav1.ObjectMeta{Name: "slack", Namespace: "ci"},
+					Spec:       tektonv1alpha1.InterceptorSpec{ClientConfig: clientConfig},
+				}),
+				FieldManager: "terraform",
+			}
+			testReadKeepsConfig(t, d, resourceTektonInterceptorRead, clients, "client_config")
+		})
+	}
+}
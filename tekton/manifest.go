@@ -0,0 +1,426 @@
+package tekton
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonscheme "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
+	triggersscheme "github.com/tektoncd/triggers/pkg/client/clientset/versioned/scheme"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	serializerjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// manifestGroups are the API groups tekton_manifest accepts.
+var manifestGroups = []string{"tekton.dev", "triggers.tekton.dev"}
+
+// tektonScheme holds the Tekton Pipelines and Triggers types.
+var tektonScheme = newTektonScheme()
+
+// manifestDecoder strictly decodes Tekton Pipelines and Triggers objects, rejecting unknown fields.
+var manifestDecoder = serializerjson.NewSerializerWithOptions(serializerjson.DefaultMetaFactory, tektonScheme, tektonScheme, serializerjson.SerializerOptions{
+	Strict: true,
+})
+
+func newTektonScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(tektonscheme.AddToScheme(scheme))
+	utilruntime.Must(triggersscheme.AddToScheme(scheme))
+	return scheme
+}
+
+// resourceTektonManifest manages any Tekton object from a raw JSON or YAML document.
+func resourceTektonManifest() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTektonManifestCreate,
+		Read:   resourceTektonManifestRead,
+		Update: resourceTektonManifestUpdate,
+		Delete: resourceTektonManifestDelete,
+
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceTektonManifestImport,
+		},
+
+		CustomizeDiff: customdiff.Sequence(customizeManifestDiff, customizeManifestLabelsDiff),
+
+		Schema: map[string]*schema.Schema{
+			"manifest": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateManifest,
+				DiffSuppressFunc: suppressEquivalentDocument,
+				Description:      "A JSON or YAML document of a tekton.dev or triggers.tekton.dev object. Namespaced objects without a namespace are created in \"default\".",
+			},
+			"api_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kind": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"namespace": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"labels_all": labelsAllSchema(),
+		},
+	}
+}
+
+func resourceTektonManifestCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+
+	object, err := decodeManifest(d.Get("manifest").(string))
+	if err != nil {
+		return err
+	}
+	applyDefaultLabels(clients.Metadata, object)
+
+	resource, err := getManifestResource(clients, object)
+	if err != nil {
+		return err
+	}
+
+	if _, err := resource.Get(context.Background(), object.GetName(), metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, object.GetKind(), object.GetNamespace(), object.GetName())
+	}
+
+	created, err := resource.Apply(context.Background(), object.GetName(), object, getManifestApplyOptions(clients))
+	if err != nil {
+		return fmt.Errorf("failed to create Tekton %s: %v", object.GetKind(), err)
+	}
+
+	d.SetId(getManifestID(created))
+	return resourceTektonManifestRead(d, m)
+}
+
+func resourceTektonManifestRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+
+	gvk, namespace, name, err := parseManifestID(d.Id())
+	if err != nil {
+		return err
+	}
+	identity := &unstructured.Unstructured{}
+	identity.SetGroupVersionKind(gvk)
+	identity.SetNamespace(namespace)
+
+	resource, err := getManifestResource(clients, identity)
+	if err != nil {
+		return err
+	}
+
+	live, err := resource.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("failed to get Tekton %s: %v", gvk.Kind, err)
+	}
+
+	// Only the fields owned by the provider's field manager and set in the configuration are
+	// compared, so that defaults and fields set by controllers do not show as drift.
+	var owned interface{} = cleanManifest(live.Object)
+	if fields, applied := getManagedFields(clients, metav1.ObjectMeta{ManagedFields: live.GetManagedFields()}); applied {
+		owned = withManifestIdentity(getOwnedContent(fields, owned), live)
+	}
+	state := owned
+	if configured := d.Get("manifest").(string); configured != "" {
+		var config interface{}
+		if err := yaml.Unmarshal([]byte(configured), &config); err == nil {
+			state = projectManifest(config, owned)
+		}
+	}
+	manifest, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to encode Tekton %s: %v", gvk.Kind, err)
+	}
+
+	d.Set("manifest", string(manifest))
+	d.Set("labels_all", getLabelsAll(clients, metav1.ObjectMeta{Labels: live.GetLabels(), ManagedFields: live.GetManagedFields()}))
+	d.Set("api_version", live.GetAPIVersion())
+	d.Set("kind", live.GetKind())
+	d.Set("name", live.GetName())
+	d.Set("namespace", live.GetNamespace())
+
+	return nil
+}
+
+func resourceTektonManifestUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+
+	object, err := decodeManifest(d.Get("manifest").(string))
+	if err != nil {
+		return err
+	}
+	applyDefaultLabels(clients.Metadata, object)
+
+	resource, err := getManifestResource(clients, object)
+	if err != nil {
+		return err
+	}
+
+	updated, err := resource.Apply(context.Background(), object.GetName(), object, getManifestApplyOptions(clients))
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton %s: %v", object.GetKind(), err)
+	}
+
+	// The apiVersion may have changed, e.g. from v1beta1 to v1.
+	d.SetId(getManifestID(updated))
+	return resourceTektonManifestRead(d, m)
+}
+
+func resourceTektonManifestDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+
+	gvk, namespace, name, err := parseManifestID(d.Id())
+	if err != nil {
+		return err
+	}
+	identity := &unstructured.Unstructured{}
+	identity.SetGroupVersionKind(gvk)
+	identity.SetNamespace(namespace)
+
+	resource, err := getManifestResource(clients, identity)
+	if err != nil {
+		return err
+	}
+
+	err = resource.Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete Tekton %s: %v", gvk.Kind, err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// resourceTektonManifestImport imports an object by an ID of the form
+// <group>/<version>/<kind>/<namespace>/<name>, or <group>/<version>/<kind>/<name> if it is cluster scoped.
+func resourceTektonManifestImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, _, _, err := parseManifestID(d.Id()); err != nil {
+		return nil, err
+	}
+	d.Set("manifest", "")
+	return []*schema.ResourceData{d}, nil
+}
+
+// customizeManifestDiff replaces the object when the manifest names a different object.
+func customizeManifestDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("manifest") || !d.NewValueKnown("manifest") {
+		return nil
+	}
+
+	object, err := decodeManifest(d.Get("manifest").(string))
+	if err != nil {
+		return nil
+	}
+	gvk, namespace, name, err := parseManifestID(d.Id())
+	if err != nil {
+		return nil
+	}
+
+	sameNamespace := object.GetNamespace() == namespace || (object.GetNamespace() == "" && (namespace == "" || namespace == "default"))
+	if object.GroupVersionKind().GroupKind() != gvk.GroupKind() || object.GetName() != name || !sameNamespace {
+		return d.ForceNew("manifest")
+	}
+	return nil
+}
+
+// customizeManifestLabelsDiff plans labels_all from the manifest's labels and the provider's default_labels.
+func customizeManifestLabelsDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	clients, ok := m.(providerClients)
+	if !ok {
+		return nil
+	}
+	if !d.NewValueKnown("manifest") {
+		return d.SetNewComputed("labels_all")
+	}
+	object, err := decodeManifest(d.Get("manifest").(string))
+	if err != nil {
+		return nil
+	}
+	return setLabelsAllDiff(d, clients.Metadata, object.GetLabels())
+}
+
+// validateManifest checks that a manifest is a named Tekton object that decodes without unknown fields.
+func validateManifest(v interface{}, k string) ([]string, []error) {
+	if _, err := decodeManifest(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q: %v", k, err)}
+	}
+	return nil, nil
+}
+
+// decodeManifest parses a JSON or YAML manifest into an unstructured object after strictly
+// decoding it with the Tekton scheme.
+func decodeManifest(manifest string) (*unstructured.Unstructured, error) {
+	raw, err := yaml.YAMLToJSON([]byte(manifest))
+	if err != nil {
+		return nil, fmt.Errorf("manifest is not valid JSON or YAML: %v", err)
+	}
+
+	object := &unstructured.Unstructured{}
+	if err := object.UnmarshalJSON(raw); err != nil {
+		return nil, fmt.Errorf("manifest is not a Kubernetes object: %v", err)
+	}
+
+	group := object.GroupVersionKind().Group
+	supported := false
+	for _, g := range manifestGroups {
+		supported = supported || group == g
+	}
+	if !supported {
+		return nil, fmt.Errorf("manifest apiVersion %q must be in one of the groups %q", object.GetAPIVersion(), manifestGroups)
+	}
+	if object.GetName() == "" {
+		return nil, fmt.Errorf("manifest must set metadata.name")
+	}
+
+	if _, _, err := manifestDecoder.Decode(raw, nil, nil); err != nil {
+		return nil, fmt.Errorf("invalid %s %s: %v", object.GetAPIVersion(), object.GetKind(), err)
+	}
+
+	return object, nil
+}
+
+// getManifestResource returns the dynamic client for an object's kind, defaulting the
+// namespace of namespaced objects to "default".
+func getManifestResource(clients providerClients, object *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := object.GroupVersionKind()
+	mapping, err := clients.RESTMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the API resource of %s %s: %v", object.GetAPIVersion(), object.GetKind(), err)
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return clients.DynamicClient.Resource(mapping.Resource), nil
+	}
+	if object.GetNamespace() == "" {
+		object.SetNamespace("default")
+	}
+	return clients.DynamicClient.Resource(mapping.Resource).Namespace(object.GetNamespace()), nil
+}
+
+// withManifestIdentity adds the apiVersion, kind, name and namespace of the live object, which
+// managed fields never include, to the owned content.
+func withManifestIdentity(owned interface{}, live *unstructured.Unstructured) interface{} {
+	object, ok := owned.(map[string]interface{})
+	if !ok {
+		return owned
+	}
+	metadata, ok := object["metadata"].(map[string]interface{})
+	if !ok {
+		metadata = map[string]interface{}{}
+		object["metadata"] = metadata
+	}
+	object["apiVersion"] = live.GetAPIVersion()
+	object["kind"] = live.GetKind()
+	metadata["name"] = live.GetName()
+	if live.GetNamespace() != "" {
+		metadata["namespace"] = live.GetNamespace()
+	}
+	return object
+}
+
+func getManifestApplyOptions(clients providerClients) metav1.ApplyOptions {
+	return metav1.ApplyOptions{
+		FieldManager: clients.FieldManager,
+		Force:        clients.ForceConflicts,
+	}
+}
+
+func applyDefaultLabels(config metadataConfig, object *unstructured.Unstructured) {
+	if len(config.DefaultLabels) == 0 {
+		return
+	}
+	labels := object.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	for k, v := range config.DefaultLabels {
+		if _, ok := labels[k]; !ok {
+			labels[k] = v
+		}
+	}
+	object.SetLabels(labels)
+}
+
+func getManifestID(object *unstructured.Unstructured) string {
+	parts := []string{object.GroupVersionKind().Group, object.GroupVersionKind().Version, object.GetKind()}
+	if object.GetNamespace() != "" {
+		parts = append(parts, object.GetNamespace())
+	}
+	return strings.Join(append(parts, object.GetName()), "/")
+}
+
+func parseManifestID(id string) (k8sschema.GroupVersionKind, string, string, error) {
+	parts := strings.Split(id, "/")
+	switch len(parts) {
+	case 4:
+		return k8sschema.GroupVersionKind{Group: parts[0], Version: parts[1], Kind: parts[2]}, "", parts[3], nil
+	case 5:
+		return k8sschema.GroupVersionKind{Group: parts[0], Version: parts[1], Kind: parts[2]}, parts[3], parts[4], nil
+	default:
+		return k8sschema.GroupVersionKind{}, "", "", fmt.Errorf("invalid ID %q, expected <group>/<version>/<kind>/[<namespace>/]<name>", id)
+	}
+}
+
+// projectManifest returns the parts of the live object that are set in the configuration.
+// Lists of a different length and values of a different type are returned whole.
+func projectManifest(config, live interface{}) interface{} {
+	switch c := config.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return live
+		}
+		projected := map[string]interface{}{}
+		for k, v := range c {
+			if lv, ok := l[k]; ok {
+				projected[k] = projectManifest(v, lv)
+			}
+		}
+		return projected
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(c) {
+			return live
+		}
+		projected := make([]interface{}, len(l))
+		for i := range l {
+			projected[i] = projectManifest(c[i], l[i])
+		}
+		return projected
+	default:
+		return live
+	}
+}
+
+// cleanManifest removes the status and server managed metadata from an imported object.
+func cleanManifest(object map[string]interface{}) map[string]interface{} {
+	cleaned := runtime.DeepCopyJSON(object)
+	delete(cleaned, "status")
+	if metadata, ok := cleaned["metadata"].(map[string]interface{}); ok {
+		for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp", "managedFields", "selfLink"} {
+			delete(metadata, field)
+		}
+	}
+	return cleaned
+}
@@ -0,0 +1,259 @@
+package tekton
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestDecodeManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{name: "YAML task", manifest: "apiVersion: tekton.dev/v1\nkind: Task\nmetadata:\n  name: build\nspec:\n  steps:\n    - name: build\n      image: golang"},
+		{name: "JSON trigger binding", manifest: `{"apiVersion":"triggers.tekton.dev/v1beta1","kind":"TriggerBinding","metadata":{"name":"push"}}`},
+		{name: "invalid YAML", manifest: "kind: [", wantErr: "not valid JSON or YAML"},
+		{name: "not an object", manifest: "- a", wantErr: "not a Kubernetes object"},
+		{name: "other group", manifest: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm", wantErr: "must be in one of the groups"},
+		{name: "no name", manifest: "apiVersion: tekton.dev/v1\nkind: Task\nmetadata:\n  generateName: build-", wantErr: "must set metadata.name"},
+		{name: "unknown field", manifest: "apiVersion: tekton.dev/v1\nkind: Task\nmetadata:\n  name: build\nspec:\n  bogus: true", wantErr: "invalid tekton.dev/v1 Task"},
+		{name: "unknown kind", manifest: "apiVersion: tekton.dev/v1\nkind: Bogus\nmetadata:\n  name: build", wantErr: "invalid tekton.dev/v1 Bogus"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			object, err := decodeManifest(tt.manifest)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("decodeManifest() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("decodeManifest() error = %v", err)
+			}
+			if object.GetName() == "" || object.GetKind() == "" {
+				t.Errorf("decodeManifest() = %v, want a named object", object)
+			}
+		})
+	}
+}
+
+func TestParseManifestID(t *testing.T) {
+	tests := []struct {
+		id            string
+		wantGVK       k8sschema.GroupVersionKind
+		wantNamespace string
+		wantName      string
+		wantErr       bool
+	}{
+		{
+			id:            "tekton.dev/v1/Task/ci/build",
+			wantGVK:       k8sschema.GroupVersionKind{Group: "tekton.dev", Version: "v1", Kind: "Task"},
+			wantNamespace: "ci",
+			wantName:      "build",
+		},
+		{
+			id:       "triggers.tekton.dev/v1alpha1/ClusterInterceptor/github",
+			wantGVK:  k8sschema.GroupVersionKind{Group: "triggers.tekton.dev", Version: "v1alpha1", Kind: "ClusterInterceptor"},
+			wantName: "github",
+		},
+		{id: "tekton.dev/v1/Task", wantErr: true},
+		{id: "tekton.dev/v1/Task/ci/build/extra", wantErr: true},
+		{id: "build", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			gvk, namespace, name, err := parseManifestID(tt.id)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseManifestID(%q) = %v %q %q, want an error", tt.id, gvk, namespace, name)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseManifestID(%q) error = %v", tt.id, err)
+			}
+			if gvk != tt.wantGVK || namespace != tt.wantNamespace || name != tt.wantName {
+				t.Errorf("parseManifestID(%q) = %v %q %q, want %v %q %q", tt.id, gvk, namespace, name, tt.wantGVK, tt.wantNamespace, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestGetManifestID(t *testing.T) {
+	tests := []struct {
+		manifest string
+		want     string
+	}{
+		{manifest: "apiVersion: tekton.dev/v1\nkind: Task\nmetadata:\n  name: build\n  namespace: ci", want: "tekton.dev/v1/Task/ci/build"},
+		{manifest: "apiVersion: triggers.tekton.dev/v1alpha1\nkind: ClusterInterceptor\nmetadata:\n  name: github", want: "triggers.tekton.dev/v1alpha1/ClusterInterceptor/github"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			object, err := decodeManifest(tt.manifest)
+			if err != nil {
+				t.Fatalf("decodeManifest() error = %v", err)
+			}
+			id := getManifestID(object)
+			if id != tt.want {
+				t.Errorf("getManifestID() = %q, want %q", id, tt.want)
+			}
+			if _, _, _, err := parseManifestID(id); err != nil {
+				t.Errorf("parseManifestID(getManifestID()) error = %v", err)
+			}
+		})
+	}
+}
+
+func TestProjectManifest(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		live   string
+		want   string
+	}{
+		{
+			name:   "keeps configured keys",
+			config: `{"spec":{"params":[{"name":"a"}]}}`,
+			live:   `{"spec":{"params":[{"name":"a","type":"string"}],"steps":[]},"status":{}}`,
+			want:   `{"spec":{"params":[{"name":"a"}]}}`,
+		},
+		{
+			name:   "configured key missing from live",
+			config: `{"spec":{"description":"x","params":[]}}`,
+			live:   `{"spec":{"params":[]}}`,
+			want:   `{"spec":{"params":[]}}`,
+		},
+		{
+			name:   "list of a different length",
+			config: `{"steps":[{"name":"a"}]}`,
+			live:   `{"steps":[{"name":"a","image":"x"},{"name":"b"}]}`,
+			want:   `{"steps":[{"name":"a","image":"x"},{"name":"b"}]}`,
+		},
+		{
+			name:   "value of a different type",
+			config: `{"spec":{"timeout":"1h"}}`,
+			live:   `{"spec":{"timeout":{"pipeline":"1h"}}}`,
+			want:   `{"spec":{"timeout":{"pipeline":"1h"}}}`,
+		},
+		{
+			name:   "changed scalar",
+			config: `{"spec":{"image":"golang:1.21"}}`,
+			live:   `{"spec":{"image":"golang:1.22"}}`,
+			want:   `{"spec":{"image":"golang:1.22"}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var config, live, want interface{}
+			mustUnmarshal(t, tt.config, &config)
+			mustUnmarshal(t, tt.live, &live)
+			mustUnmarshal(t, tt.want, &want)
+
+			if got := projectManifest(config, live); !reflect.DeepEqual(got, want) {
+				t.Errorf("projectManifest() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestCleanManifest(t *testing.T) {
+	var object, want map[string]interface{}
+	mustUnmarshal(t, `{"kind":"Task","metadata":{"name":"build","uid":"1","resourceVersion":"2","generation":3,"creationTimestamp":"x","managedFields":[],"labels":{"a":"b"}},"spec":{},"status":{}}`, &object)
+	mustUnmarshal(t, `{"kind":"Task","metadata":{"name":"build","labels":{"a":"b"}},"spec":{}}`, &want)
+
+	if got := cleanManifest(object); !reflect.DeepEqual(got, want) {
+		t.Errorf("cleanManifest() = %v, want %v", got, want)
+	}
+	if _, ok := object["status"]; !ok {
+		t.Errorf("cleanManifest() modified its argument")
+	}
+}
+
+func TestCustomizeManifestDiff(t *testing.T) {
+	const task = "apiVersion: tekton.dev/v1\nkind: Task\nmetadata:\n  name: build\n  namespace: ci\nspec:\n  description: a"
+
+	tests := []struct {
+		name            string
+		id              string
+		manifest        string
+		wantRequiresNew bool
+	}{
+		{
+			name:     "spec change",
+			id:       "tekton.dev/v1/Task/ci/build",
+			manifest: strings.Replace(task, "description: a", "description: b", 1),
+		},
+		{
+			name:     "version change",
+			id:       "tekton.dev/v1beta1/Task/ci/build",
+			manifest: strings.Replace(task, "description: a", "description: b", 1),
+		},
+		{
+			name:            "name change",
+			id:              "tekton.dev/v1/Task/ci/build",
+			manifest:        strings.Replace(task, "name: build", "name: test", 1),
+			wantRequiresNew: true,
+		},
+		{
+			name:            "namespace change",
+			id:              "tekton.dev/v1/Task/ci/build",
+			manifest:        strings.Replace(task, "namespace: ci", "namespace: cd", 1),
+			wantRequiresNew: true,
+		},
+		{
+			name:     "namespace defaulted",
+			id:       "tekton.dev/v1/Task/default/build",
+			manifest: strings.Replace(task, "  namespace: ci\n", "", 1),
+		},
+		{
+			name:            "kind change",
+			id:              "tekton.dev/v1/Task/ci/build",
+			manifest:        strings.Replace(task, "kind: Task", "kind: Pipeline", 1),
+			wantRequiresNew: true,
+		},
+		{
+			name:            "group change",
+			id:              "tekton.dev/v1/Task/ci/build",
+			manifest:        "apiVersion: triggers.tekton.dev/v1beta1\nkind: TriggerBinding\nmetadata:\n  name: build\n  namespace: ci",
+			wantRequiresNew: true,
+		},
+		{
+			name:            "cluster scoped to namespaced",
+			id:              "triggers.tekton.dev/v1alpha1/ClusterInterceptor/build",
+			manifest:        "apiVersion: triggers.tekton.dev/v1alpha1\nkind: Interceptor\nmetadata:\n  name: build\n  namespace: ci",
+			wantRequiresNew: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &terraform.InstanceState{
+				ID:         tt.id,
+				Attributes: map[string]string{"manifest": task},
+			}
+			config := terraform.NewResourceConfigRaw(map[string]interface{}{"manifest": tt.manifest})
+
+			diff, err := resourceTektonManifest().Diff(context.Background(), state, config, nil)
+			if err != nil {
+				t.Fatalf("Diff() error = %v", err)
+			}
+			if got := diff.RequiresNew(); got != tt.wantRequiresNew {
+				t.Errorf("RequiresNew() = %v, want %v", got, tt.wantRequiresNew)
+			}
+		})
+	}
+}
+
+func mustUnmarshal(t *testing.T, data string, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		t.Fatalf("invalid test JSON %s: %v", data, err)
+	}
+}
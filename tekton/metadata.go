@@ -0,0 +1,196 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// metadataConfig holds the provider level settings applied to the labels and annotations of every object.
+type metadataConfig struct {
+	DefaultLabels     map[string]string
+	IgnoreLabels      []*regexp.Regexp
+	IgnoreAnnotations []*regexp.Regexp
+}
+
+// metadataSchema defines the labels and annotations of a Tekton object.
+func metadataSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"labels": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Labels of the object, merged over the provider's default_labels.",
+		},
+		"annotations": {
+			Type:     schema.TypeMap,
+			Optional: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"labels_all": labelsAllSchema(),
+	}
+}
+
+// runMetadataSchema defines the labels and annotations of a run. Runs are not relabelled when
+// default_labels change, so they have no labels_all.
+func runMetadataSchema() map[string]*schema.Schema {
+	schemas := metadataSchema()
+	delete(schemas, "labels_all")
+	return schemas
+}
+
+// labelsAllSchema defines the labels the provider sets on an object: its labels merged over the
+// provider's default_labels. A change of default_labels shows as a change of labels_all.
+func labelsAllSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeMap,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The labels set on the object, including the provider's default_labels.",
+	}
+}
+
+// customizeLabelsDiff plans labels_all from the resource's labels and the provider's default_labels.
+func customizeLabelsDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	clients, ok := m.(providerClients)
+	if !ok {
+		return nil
+	}
+	if !rawPlanKnown(d, "labels") {
+		return d.SetNewComputed("labels_all")
+	}
+	return setLabelsAllDiff(d, clients.Metadata, toStringMap(d.Get("labels").(map[string]interface{})))
+}
+
+// setLabelsAllDiff plans labels_all as labels merged over the default labels, leaving out ignored keys.
+func setLabelsAllDiff(d *schema.ResourceDiff, config metadataConfig, labels map[string]string) error {
+	labelsAll := map[string]interface{}{}
+	for k, v := range config.DefaultLabels {
+		labelsAll[k] = v
+	}
+	for k, v := range labels {
+		labelsAll[k] = v
+	}
+	for k := range labelsAll {
+		if matchesAny(config.IgnoreLabels, k) {
+			delete(labelsAll, k)
+		}
+	}
+
+	if reflect.DeepEqual(d.Get("labels_all").(map[string]interface{}), labelsAll) {
+		return nil
+	}
+	return d.SetNew("labels_all", labelsAll)
+}
+
+// getLabelsAll returns the labels of an object the provider owns, leaving out ignored keys.
+func getLabelsAll(clients providerClients, meta metav1.ObjectMeta) map[string]interface{} {
+	fields, applied := getManagedFields(clients, meta)
+	labelsAll := map[string]interface{}{}
+	for k, v := range meta.Labels {
+		if matchesAny(clients.Metadata.IgnoreLabels, k) || (applied && !ownsField(fields, "metadata", "labels", k)) {
+			continue
+		}
+		labelsAll[k] = v
+	}
+	return labelsAll
+}
+
+// getMetadataConfig builds the metadata settings from the provider configuration.
+func getMetadataConfig(d *schema.ResourceData) (metadataConfig, error) {
+	config := metadataConfig{
+		DefaultLabels: toStringMap(d.Get("default_labels").(map[string]interface{})),
+	}
+
+	var err error
+	if config.IgnoreLabels, err = compilePatterns(d.Get("ignore_labels").([]interface{})); err != nil {
+		return config, fmt.Errorf("invalid ignore_labels: %v", err)
+	}
+	if config.IgnoreAnnotations, err = compilePatterns(d.Get("ignore_annotations").([]interface{})); err != nil {
+		return config, fmt.Errorf("invalid ignore_annotations: %v", err)
+	}
+	return config, nil
+}
+
+func compilePatterns(tfPatterns []interface{}) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for _, tfPattern := range tfPatterns {
+		pattern, err := regexp.Compile(tfPattern.(string))
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+func matchesAny(patterns []*regexp.Regexp, key string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// getObjectLabels returns the provider's default labels merged with the resource's labels.
+func getObjectLabels(config metadataConfig, d *schema.ResourceData) map[string]string {
+	labels := map[string]string{}
+	for k, v := range config.DefaultLabels {
+		labels[k] = v
+	}
+	for k, v := range d.Get("labels").(map[string]interface{}) {
+		labels[k] = v.(string)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// getObjectMeta returns the metadata of a new object.
+func getObjectMeta(config metadataConfig, d *schema.ResourceData, name, namespace string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:        name,
+		Namespace:   namespace,
+		Labels:      getObjectLabels(config, d),
+		Annotations: toStringMap(d.Get("annotations").(map[string]interface{})),
+	}
+}
+
+// setObjectMeta reads the labels and annotations of an object into state, leaving out ignored
+// keys, keys owned by other field managers and default labels that are not set on the resource itself.
+func setObjectMeta(clients providerClients, d *schema.ResourceData, meta metav1.ObjectMeta) {
+	config := clients.Metadata
+	configured := d.Get("labels").(map[string]interface{})
+	fields, applied := getManagedFields(clients, meta)
+
+	labels := map[string]interface{}{}
+	for k, v := range meta.Labels {
+		if matchesAny(config.IgnoreLabels, k) || (applied && !ownsField(fields, "metadata", "labels", k)) {
+			continue
+		}
+		if defaultValue, ok := config.DefaultLabels[k]; ok && defaultValue == v {
+			if _, set := configured[k]; !set {
+				continue
+			}
+		}
+		labels[k] = v
+	}
+
+	annotations := map[string]interface{}{}
+	for k, v := range meta.Annotations {
+		if matchesAny(config.IgnoreAnnotations, k) || (applied && !ownsField(fields, "metadata", "annotations", k)) {
+			continue
+		}
+		annotations[k] = v
+	}
+
+	d.Set("labels", labels)
+	d.Set("annotations", annotations)
+	d.Set("labels_all", getLabelsAll(clients, meta))
+}
@@ -6,9 +6,9 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
-	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
-	triggersclient "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // resourceTektonPipeline defines a Tekton Pipeline resource.
@@ -19,64 +19,85 @@ func resourceTektonPipeline() *schema.Resource {
 		Update: resourceTektonPipelineUpdate,
 		Delete: resourceTektonPipelineDelete,
 
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: customizeLabelsDiff,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"namespace": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "default",
+				ForceNew: true,
 			},
-			"tasks": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"task_ref_name": {
-							Type:        schema.TypeString,
-							Required:    true,
-							Description: "The name of the Tekton Task to reference in this Pipeline",
-						},
-						"run_after": {
-							Type:        schema.TypeList,
-							Optional:    true,
-							Elem:        &schema.Schema{Type: schema.TypeString},
-							Description: "Tasks that should run after this task.",
-						},
-						"workspaces": {
-							Type:     schema.TypeList,
-							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"name": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-									"workspace_ref": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
+		}, pipelineSpecSchema(), metadataSchema()),
+	}
+}
+
+// pipelineSpecSchema defines the fields of a Pipeline spec, shared with inline pipeline_spec blocks.
+func pipelineSpecSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"tasks": {
+			Type:     schema.TypeList,
+			Required: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"task_ref_name": {
+						Type:        schema.TypeString,
+						Required:    true,
+						Description: "The name of the Tekton Task to reference in this Pipeline",
+					},
+					"task_ref_kind": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     string(tektonv1beta1.NamespacedTaskKind),
+						Description: "The kind of the referenced task: Task, ClusterTask, or the kind of a custom task when task_ref_api_version is set.",
+					},
+					"task_ref_api_version": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The apiVersion of a custom task, e.g. \"example.dev/v1alpha1\". The task then runs as a CustomRun.",
+					},
+					"run_after": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Elem:        &schema.Schema{Type: schema.TypeString},
+						Description: "Tasks that should run after this task.",
+					},
+					"workspaces": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:     schema.TypeString,
+									Required: true,
+								},
+								"workspace_ref": {
+									Type:     schema.TypeString,
+									Required: true,
 								},
 							},
 						},
 					},
 				},
 			},
-			"workspaces": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
+		},
+		"workspaces": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
 					},
 				},
 			},
@@ -117,28 +138,23 @@ func getPipelineTaskWorkspaces(tfWorkspaces []interface{}) []tektonv1beta1.Works
 
 // resourceTektonPipelineCreate creates a Tekton Pipeline.
 func resourceTektonPipelineCreate(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Get("name").(string)
 	namespace := d.Get("namespace").(string)
 
-	tasks := getPipelineTasks(d.Get("tasks").([]interface{}))
-	workspaces := getPipelineWorkspaces(d.Get("workspaces").([]interface{}))
-
 	pipeline := &tektonv1beta1.Pipeline{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: tektonv1beta1.PipelineSpec{
-			Tasks:      tasks,
-			Workspaces: workspaces,
-		},
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       getPipelineSpec(d.Get("tasks").([]interface{}), d.Get("workspaces").([]interface{})),
+	}
+
+	if _, err := clients.TektonClient.TektonV1beta1().Pipelines(namespace).Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "Pipeline", namespace, name)
 	}
 
-	_, err := clients.TektonClient.TektonV1beta1().Pipelines(namespace).Create(context.Background(), pipeline, metav1.CreateOptions{})
+	patch, err := getApplyPatch(pipeline)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1beta1().Pipelines(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create Tekton Pipeline: %v", err)
 	}
@@ -149,35 +165,50 @@ func resourceTektonPipelineCreate(d *schema.ResourceData, m interface{}) error {
 
 // resourceTektonPipelineRead reads the state of a Tekton Pipeline.
 func resourceTektonPipelineRead(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
-	_, err := clients.TektonClient.TektonV1beta1().Pipelines(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	pipeline, err := clients.TektonClient.TektonV1beta1().Pipelines(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
 		// If the pipeline is not found, remove it from the state
 		d.SetId("")
 		return nil
 	}
 
+	setObjectMeta(clients, d, pipeline.ObjectMeta)
+
+	setManagedField(clients, d, pipeline.ObjectMeta, "tasks", flattenPipelineSpecTasks(pipeline.Spec.Tasks), "spec", "tasks")
+	setManagedField(clients, d, pipeline.ObjectMeta, "workspaces", flattenPipelineWorkspaces(pipeline.Spec.Workspaces), "spec", "workspaces")
+
 	return nil
 }
 
 // resourceTektonPipelineUpdate updates a Tekton Pipeline.
 func resourceTektonPipelineUpdate(d *schema.ResourceData, m interface{}) error {
-	// Tekton Pipelines are typically immutable once created. You may handle update logic based on your needs.
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	pipeline := &tektonv1beta1.Pipeline{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       getPipelineSpec(d.Get("tasks").([]interface{}), d.Get("workspaces").([]interface{})),
+	}
+
+	patch, err := getApplyPatch(pipeline)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1beta1().Pipelines(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton Pipeline: %v", err)
+	}
+
 	return resourceTektonPipelineRead(d, m)
 }
 
 // resourceTektonPipelineDelete deletes a Tekton Pipeline.
 func resourceTektonPipelineDelete(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
@@ -190,6 +221,14 @@ func resourceTektonPipelineDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// Helper function to build a Tekton pipeline spec from Terraform tasks and workspaces
+func getPipelineSpec(tfTasks []interface{}, tfWorkspaces []interface{}) tektonv1beta1.PipelineSpec {
+	return tektonv1beta1.PipelineSpec{
+		Tasks:      getPipelineTasks(tfTasks),
+		Workspaces: getPipelineWorkspaces(tfWorkspaces),
+	}
+}
+
 // Helper function to convert Terraform tasks into Tekton pipeline tasks
 func getPipelineTasks(tfTasks []interface{}) []tektonv1beta1.PipelineTask {
 	var tasks []tektonv1beta1.PipelineTask
@@ -199,7 +238,9 @@ func getPipelineTasks(tfTasks []interface{}) []tektonv1beta1.PipelineTask {
 		task := tektonv1beta1.PipelineTask{
 			Name: taskData["name"].(string),
 			TaskRef: &tektonv1beta1.TaskRef{
-				Name: taskData["task_ref_name"].(string),
+				Name:       taskData["task_ref_name"].(string),
+				Kind:       tektonv1beta1.TaskKind(taskData["task_ref_kind"].(string)),
+				APIVersion: taskData["task_ref_api_version"].(string),
 			},
 		}
 
@@ -207,9 +248,49 @@ func getPipelineTasks(tfTasks []interface{}) []tektonv1beta1.PipelineTask {
 		if v, ok := taskData["run_after"]; ok {
 			task.RunAfter = toStringSlice(v.([]interface{}))
 		}
+		if v, ok := taskData["workspaces"]; ok {
+			task.Workspaces = getPipelineTaskWorkspaces(v.([]interface{}))
+		}
 
 		tasks = append(tasks, task)
 	}
 
 	return tasks
 }
+
+// flattenPipelineSpecTasks converts Tekton pipeline tasks into the tasks of a pipeline spec block.
+func flattenPipelineSpecTasks(pipelineTasks []tektonv1beta1.PipelineTask) []interface{} {
+	var tfTasks []interface{}
+	for _, pipelineTask := range pipelineTasks {
+		var workspaces []interface{}
+		for _, workspace := range pipelineTask.Workspaces {
+			workspaces = append(workspaces, map[string]interface{}{
+				"name":          workspace.Name,
+				"workspace_ref": workspace.Workspace,
+			})
+		}
+
+		tfTask := map[string]interface{}{
+			"name":       pipelineTask.Name,
+			"run_after":  pipelineTask.RunAfter,
+			"workspaces": workspaces,
+		}
+		if pipelineTask.TaskRef != nil {
+			tfTask["task_ref_name"] = pipelineTask.TaskRef.Name
+			tfTask["task_ref_kind"] = string(pipelineTask.TaskRef.Kind)
+			tfTask["task_ref_api_version"] = pipelineTask.TaskRef.APIVersion
+		}
+		tfTasks = append(tfTasks, tfTask)
+	}
+	return tfTasks
+}
+
+func flattenPipelineWorkspaces(workspaces []tektonv1beta1.PipelineWorkspaceDeclaration) []interface{} {
+	var tfWorkspaces []interface{}
+	for _, workspace := range workspaces {
+		tfWorkspaces = append(tfWorkspaces, map[string]interface{}{
+			"name": workspace.Name,
+		})
+	}
+	return tfWorkspaces
+}
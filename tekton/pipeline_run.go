@@ -3,12 +3,14 @@ package tekton
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
-	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
-	triggersclient "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // resourceTektonPipelineRun defines a Tekton PipelineRun resource.
@@ -19,20 +21,44 @@ func resourceTektonPipelineRun() *schema.Resource {
 		Update: resourceTektonPipelineRunUpdate,
 		Delete: resourceTektonPipelineRunDelete,
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+		CustomizeDiff: customdiff.Sequence(
+			customizeRunDiff,
+			// A started PipelineRun cannot be made pending again.
+			customdiff.ForceNewIfChange("pending", func(ctx context.Context, old, new, m interface{}) bool {
+				return !old.(bool) && new.(bool)
+			}),
+		),
+
+		Schema: mergeSchemas(runTriggerSchema(), runDestroySchema(), map[string]*schema.Schema{
+			"cancel_status": pipelineRunCancelStatusSchema(),
+			"pending": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Create the PipelineRun as pending. Setting this back to false starts the run.",
 			},
+			"capture_logs": captureLogsSchema(),
+		}, forceNewSchemas(map[string]*schema.Schema{
 			"namespace": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "default",
 			},
 			"pipeline_ref_name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the Tekton Pipeline to reference in this PipelineRun.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"pipeline_ref_name", "pipeline_spec"},
+				Description:  "The name of the Tekton Pipeline to reference in this PipelineRun.",
+			},
+			"pipeline_spec": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"pipeline_ref_name", "pipeline_spec"},
+				Description:  "An inline Pipeline definition to run instead of referencing one.",
+				Elem: &schema.Resource{
+					Schema: pipelineSpecSchema(),
+				},
 			},
 			"service_account_name": {
 				Type:     schema.TypeString,
@@ -55,55 +81,48 @@ func resourceTektonPipelineRun() *schema.Resource {
 					},
 				},
 			},
-		},
+			"pipeline_timeouts": pipelineTimeoutsSchema(),
+			"pod_template":      podTemplateSchema(),
+			"task_run_specs":    taskRunSpecsSchema(),
+		}), forceNewSchemas(runMetadataSchema())),
 	}
 }
 
 // resourceTektonPipelineRunCreate creates a Tekton PipelineRun.
 func resourceTektonPipelineRunCreate(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
-	name := d.Get("name").(string)
+	clients := m.(providerClients)
 	namespace := d.Get("namespace").(string)
-	pipelineRefName := d.Get("pipeline_ref_name").(string)
-	serviceAccountName := d.Get("service_account_name").(string)
 
-	params := getPipelineRunParams(d.Get("params").([]interface{}))
-
-	pipelineRun := &tektonv1beta1.PipelineRun{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: tektonv1beta1.PipelineRunSpec{
-			PipelineRef: &tektonv1beta1.PipelineRef{
-				Name: pipelineRefName,
-			},
-			ServiceAccountName: serviceAccountName,
-			Params:             params,
-		},
+	pipelineRun, err := getPipelineRun(clients.Metadata, d)
+	if err != nil {
+		return err
 	}
 
-	_, err := clients.TektonClient.TektonV1beta1().PipelineRuns(namespace).Create(context.Background(), pipelineRun, metav1.CreateOptions{})
+	created, err := clients.TektonClient.TektonV1beta1().PipelineRuns(namespace).Create(context.Background(), pipelineRun, metav1.CreateOptions{FieldManager: clients.FieldManager})
 	if err != nil {
 		return fmt.Errorf("failed to create Tekton PipelineRun: %v", err)
 	}
 
-	d.SetId(name)
+	d.SetId(created.Name)
+	d.Set("name", created.Name)
+
+	if config := getCaptureLogsConfig(d.Get("capture_logs").([]interface{})); config != nil && !d.Get("pending").(bool) {
+		if err := waitForPipelineRunLogs(clients, namespace, created.Name, config); err != nil {
+			return err
+		}
+	}
+
 	return resourceTektonPipelineRunRead(d, m)
 }
 
 // resourceTektonPipelineRunRead reads the state of a Tekton PipelineRun.
 func resourceTektonPipelineRunRead(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
+	// pending is not refreshed: a run released or timed out outside of Terraform keeps its
+	// configured value, so that it does not plan a replacement.
 	_, err := clients.TektonClient.TektonV1beta1().PipelineRuns(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
 		// If the pipeline run is not found, remove it from the state
@@ -116,28 +135,112 @@ func resourceTektonPipelineRunRead(d *schema.ResourceData, m interface{}) error
 
 // resourceTektonPipelineRunUpdate updates a Tekton PipelineRun (if necessary).
 func resourceTektonPipelineRunUpdate(d *schema.ResourceData, m interface{}) error {
-	// PipelineRuns are typically immutable once created. Handle as necessary.
-	return resourceTektonPipelineRunRead(d, m)
+	// PipelineRuns are immutable once created. In generate_name mode a change of
+	// triggers starts a fresh run instead of replacing the resource, and a pending
+	// run is started when pending is switched off.
+	clients := m.(providerClients)
+	previous := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	if !d.HasChange("triggers") {
+		if d.HasChange("pending") && !d.Get("pending").(bool) {
+			_, err := clients.TektonClient.TektonV1beta1().PipelineRuns(namespace).Patch(context.Background(), previous, types.MergePatchType, []byte(`{"spec":{"status":null}}`), metav1.PatchOptions{FieldManager: clients.FieldManager})
+			if err != nil {
+				return fmt.Errorf("failed to start pending Tekton PipelineRun: %v", err)
+			}
+			if config := getCaptureLogsConfig(d.Get("capture_logs").([]interface{})); config != nil {
+				if err := waitForPipelineRunLogs(clients, namespace, previous, config); err != nil {
+					return err
+				}
+			}
+		}
+		return resourceTektonPipelineRunRead(d, m)
+	}
+
+	if err := resourceTektonPipelineRunCreate(d, m); err != nil {
+		return err
+	}
+
+	if !d.Get("keep_previous_runs").(bool) {
+		if err := destroyTektonPipelineRun(clients, d, previous); err != nil {
+			return fmt.Errorf("failed to clean up previous Tekton PipelineRun %s: %v", previous, err)
+		}
+	}
+
+	return nil
 }
 
 // resourceTektonPipelineRunDelete deletes a Tekton PipelineRun.
 func resourceTektonPipelineRunDelete(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
-	name := d.Id()
+	clients := m.(providerClients)
+
+	if err := destroyTektonPipelineRun(clients, d, d.Id()); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// destroyTektonPipelineRun deletes, cancels or keeps a PipelineRun according to on_destroy. It is
+// used both on destroy and for the previous run when triggers start a new one.
+func destroyTektonPipelineRun(clients providerClients, d *schema.ResourceData, name string) error {
 	namespace := d.Get("namespace").(string)
 
+	switch d.Get("on_destroy").(string) {
+	case onDestroyKeep:
+		return nil
+	case onDestroyCancel:
+		timeout, _ := time.ParseDuration(d.Get("cancel_timeout").(string))
+		return cancelPipelineRun(clients, namespace, name, d.Get("cancel_status").(string), timeout)
+	}
+
 	err := clients.TektonClient.TektonV1beta1().PipelineRuns(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return fmt.Errorf("failed to delete Tekton PipelineRun: %v", err)
 	}
-
-	d.SetId("")
 	return nil
 }
 
+// Helper function to build a Tekton PipelineRun from the resource configuration
+func getPipelineRun(config metadataConfig, d *schema.ResourceData) (*tektonv1beta1.PipelineRun, error) {
+	timeouts, err := getPipelineRunTimeouts(d.Get("pipeline_timeouts").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	taskRunSpecs, err := getPipelineTaskRunSpecs(d.Get("task_run_specs").([]interface{}), d.GetRawConfig().GetAttr("task_run_specs"))
+	if err != nil {
+		return nil, err
+	}
+
+	pipelineRun := &tektonv1beta1.PipelineRun{
+		ObjectMeta: getRunObjectMeta(config, d),
+		Spec: tektonv1beta1.PipelineRunSpec{
+			ServiceAccountName: d.Get("service_account_name").(string),
+			Params:             getPipelineRunParams(d.Get("params").([]interface{})),
+			Timeouts:           timeouts,
+			PodTemplate:        getPodTemplate(d.Get("pod_template").([]interface{}), d.GetRawConfig().GetAttr("pod_template")),
+			TaskRunSpecs:       taskRunSpecs,
+		},
+	}
+
+	if d.Get("pending").(bool) {
+		pipelineRun.Spec.Status = tektonv1beta1.PipelineRunSpecStatusPending
+	}
+
+	if v, ok := d.GetOk("pipeline_spec"); ok {
+		specData := v.([]interface{})[0].(map[string]interface{})
+		pipelineSpec := getPipelineSpec(specData["tasks"].([]interface{}), specData["workspaces"].([]interface{}))
+		pipelineRun.Spec.PipelineSpec = &pipelineSpec
+	} else {
+		pipelineRun.Spec.PipelineRef = &tektonv1beta1.PipelineRef{
+			Name: d.Get("pipeline_ref_name").(string),
+		}
+	}
+
+	return pipelineRun, nil
+}
+
 // Helper function to convert Terraform params into Tekton params
 func getPipelineRunParams(tfParams []interface{}) []tektonv1beta1.Param {
 	var params []tektonv1beta1.Param
@@ -0,0 +1,34 @@
+package tekton
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonfake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceTektonPipelineRunReadKeepsPending(t *testing.T) {
+	// The run was released outside of Terraform.
+	client := tektonfake.NewSimpleClientset(&tektonv1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "ci"},
+	})
+	d := schema.TestResourceDataRaw(t, resourceTektonPipelineRun().Schema, map[string]interface{}{
+		"name":              "release",
+		"namespace":         "ci",
+		"pipeline_ref_name": "release",
+		"pending":           true,
+	})
+	d.SetId("release")
+
+	if err := resourceTektonPipelineRunRead(d, providerClients{TektonClient: client}); err != nil {
+		t.Fatalf("resourceTektonPipelineRunRead() error = %v", err)
+	}
+	if d.Id() != "release" {
+		t.Fatalf("id = %q, want release", d.Id())
+	}
+	if !d.Get("pending").(bool) {
+		t.Errorf("pending = false, want the configured true")
+	}
+}
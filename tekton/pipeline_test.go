@@ -0,0 +1,41 @@
+package tekton
+
+import (
+	"testing"
+
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonfake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceTektonPipelineRead(t *testing.T) {
+	d := testResourceData(t, resourceTektonPipeline(), map[string]interface{}{
+		"name":      "release",
+		"namespace": "ci",
+		"tasks": []interface{}{
+			map[string]interface{}{
+				"name":          "build",
+				"task_ref_name": "build",
+				"workspaces":    []interface{}{map[string]interface{}{"name": "source", "workspace_ref": "shared"}},
+			},
+			map[string]interface{}{
+				"name":          "deploy",
+				"task_ref_name": "deploy",
+				"task_ref_kind": "ClusterTask",
+				"run_after":     []interface{}{"build"},
+			},
+		},
+		"workspaces": []interface{}{map[string]interface{}{"name": "shared"}},
+	})
+	spec := getPipelineSpec(d.Get("tasks").([]interface{}), d.Get("workspaces").([]interface{}))
+	d.SetId("release")
+
+	clients := providerClients{
+		TektonClient: tektonfake.NewSimpleClientset(&tektonv1beta1.Pipeline{
+			ObjectMeta: metav1.ObjectMeta{Name: "release", Namespace: "ci"},
+			Spec:       spec,
+		}),
+		FieldManager: "terraform",
+	}
+	testReadKeepsConfig(t, d, resourceTektonPipelineRead, clients, "tasks", "workspaces")
+}
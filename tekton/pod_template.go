@@ -0,0 +1,532 @@
+package tekton
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// podTemplateSchema defines the pod_template block shared by PipelineRuns, TaskRuns and task_run_specs.
+func podTemplateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"node_selector": {
+					Type:     schema.TypeMap,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"tolerations": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"key": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"operator": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringInSlice([]string{"Equal", "Exists"}, false),
+							},
+							"value": {
+								Type:     schema.TypeString,
+								Optional: true,
+							},
+							"effect": {
+								Type:         schema.TypeString,
+								Optional:     true,
+								ValidateFunc: validation.StringInSlice([]string{"NoSchedule", "PreferNoSchedule", "NoExecute"}, false),
+							},
+							"toleration_seconds": {
+								Type:     schema.TypeInt,
+								Optional: true,
+							},
+						},
+					},
+				},
+				"affinity": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"node_affinity": {
+								Type:     schema.TypeList,
+								Optional: true,
+								MaxItems: 1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"required": {
+											Type:        schema.TypeList,
+											Optional:    true,
+											Description: "Node selector terms that must match for the pod to be scheduled.",
+											Elem: &schema.Resource{
+												Schema: map[string]*schema.Schema{
+													"match_expressions": nodeSelectorRequirementSchema(),
+												},
+											},
+										},
+										"preferred": {
+											Type:        schema.TypeList,
+											Optional:    true,
+											Description: "Weighted node selector terms the scheduler prefers.",
+											Elem: &schema.Resource{
+												Schema: map[string]*schema.Schema{
+													"weight": {
+														Type:         schema.TypeInt,
+														Required:     true,
+														ValidateFunc: validation.IntBetween(1, 100),
+													},
+													"match_expressions": nodeSelectorRequirementSchema(),
+												},
+											},
+										},
+									},
+								},
+							},
+							"pod_affinity":      podAffinityTermsSchema(),
+							"pod_anti_affinity": podAffinityTermsSchema(),
+						},
+					},
+				},
+				"security_context": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"run_as_user": {
+								Type:     schema.TypeInt,
+								Optional: true,
+							},
+							"run_as_group": {
+								Type:     schema.TypeInt,
+								Optional: true,
+							},
+							"run_as_non_root": {
+								Type:     schema.TypeBool,
+								Optional: true,
+							},
+							"fs_group": {
+								Type:     schema.TypeInt,
+								Optional: true,
+							},
+							"supplemental_groups": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeInt},
+							},
+						},
+					},
+				},
+				"image_pull_secrets": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Names of secrets used to pull the step images.",
+				},
+				"host_aliases": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"ip": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"hostnames": {
+								Type:     schema.TypeList,
+								Required: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+						},
+					},
+				},
+				"dns_config": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"nameservers": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+							"searches": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem:     &schema.Schema{Type: schema.TypeString},
+							},
+							"options": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"name": {
+											Type:     schema.TypeString,
+											Required: true,
+										},
+										"value": {
+											Type:     schema.TypeString,
+											Optional: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"runtime_class_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"scheduler_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+			},
+		},
+	}
+}
+
+func nodeSelectorRequirementSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"operator": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringInSlice([]string{"In", "NotIn", "Exists", "DoesNotExist", "Gt", "Lt"}, false),
+				},
+				"values": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+func podAffinityTermsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Required pod (anti-)affinity terms.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"topology_key": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"match_labels": {
+					Type:     schema.TypeMap,
+					Required: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+				"namespaces": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+}
+
+// Helper function to convert a Terraform pod_template block into a Tekton pod template. The raw
+// config of the block tells attributes set to 0, such as run_as_user, apart from unset ones.
+func getPodTemplate(tfPodTemplate []interface{}, rawPodTemplate cty.Value) *pod.PodTemplate {
+	if len(tfPodTemplate) == 0 || tfPodTemplate[0] == nil {
+		return nil
+	}
+	templateData := tfPodTemplate[0].(map[string]interface{})
+	template := &pod.PodTemplate{
+		NodeSelector:     toStringMap(templateData["node_selector"].(map[string]interface{})),
+		Tolerations:      getTolerations(templateData["tolerations"].([]interface{}), rawConfigAt(rawPodTemplate, 0, "tolerations")),
+		Affinity:         getAffinity(templateData["affinity"].([]interface{})),
+		SecurityContext:  getPodSecurityContext(templateData["security_context"].([]interface{}), rawConfigAt(rawPodTemplate, 0, "security_context")),
+		ImagePullSecrets: getLocalObjectReferences(templateData["image_pull_secrets"].([]interface{})),
+		HostAliases:      getHostAliases(templateData["host_aliases"].([]interface{})),
+		DNSConfig:        getPodDNSConfig(templateData["dns_config"].([]interface{})),
+		SchedulerName:    templateData["scheduler_name"].(string),
+	}
+
+	if v := templateData["runtime_class_name"].(string); v != "" {
+		template.RuntimeClassName = &v
+	}
+
+	return template
+}
+
+func getTolerations(tfTolerations []interface{}, rawTolerations cty.Value) []corev1.Toleration {
+	var tolerations []corev1.Toleration
+	for i, tfToleration := range tfTolerations {
+		tolerationData := tfToleration.(map[string]interface{})
+		toleration := corev1.Toleration{
+			Key:      tolerationData["key"].(string),
+			Operator: corev1.TolerationOperator(tolerationData["operator"].(string)),
+			Value:    tolerationData["value"].(string),
+			Effect:   corev1.TaintEffect(tolerationData["effect"].(string)),
+		}
+		if v := int64(tolerationData["toleration_seconds"].(int)); v != 0 || rawConfigSet(rawTolerations, i, "toleration_seconds") {
+			toleration.TolerationSeconds = &v
+		}
+		tolerations = append(tolerations, toleration)
+	}
+	return tolerations
+}
+
+func getAffinity(tfAffinity []interface{}) *corev1.Affinity {
+	if len(tfAffinity) == 0 || tfAffinity[0] == nil {
+		return nil
+	}
+	affinityData := tfAffinity[0].(map[string]interface{})
+	affinity := &corev1.Affinity{}
+
+	if v := affinityData["node_affinity"].([]interface{}); len(v) > 0 && v[0] != nil {
+		nodeAffinityData := v[0].(map[string]interface{})
+		nodeAffinity := &corev1.NodeAffinity{}
+
+		var terms []corev1.NodeSelectorTerm
+		for _, tfTerm := range nodeAffinityData["required"].([]interface{}) {
+			termData := tfTerm.(map[string]interface{})
+			terms = append(terms, corev1.NodeSelectorTerm{
+				MatchExpressions: getNodeSelectorRequirements(termData["match_expressions"].([]interface{})),
+			})
+		}
+		if len(terms) > 0 {
+			nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{NodeSelectorTerms: terms}
+		}
+
+		for _, tfTerm := range nodeAffinityData["preferred"].([]interface{}) {
+			termData := tfTerm.(map[string]interface{})
+			nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(nodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.PreferredSchedulingTerm{
+				Weight: int32(termData["weight"].(int)),
+				Preference: corev1.NodeSelectorTerm{
+					MatchExpressions: getNodeSelectorRequirements(termData["match_expressions"].([]interface{})),
+				},
+			})
+		}
+
+		affinity.NodeAffinity = nodeAffinity
+	}
+
+	if terms := getPodAffinityTerms(affinityData["pod_affinity"].([]interface{})); len(terms) > 0 {
+		affinity.PodAffinity = &corev1.PodAffinity{RequiredDuringSchedulingIgnoredDuringExecution: terms}
+	}
+	if terms := getPodAffinityTerms(affinityData["pod_anti_affinity"].([]interface{})); len(terms) > 0 {
+		affinity.PodAntiAffinity = &corev1.PodAntiAffinity{RequiredDuringSchedulingIgnoredDuringExecution: terms}
+	}
+
+	return affinity
+}
+
+func getNodeSelectorRequirements(tfRequirements []interface{}) []corev1.NodeSelectorRequirement {
+	var requirements []corev1.NodeSelectorRequirement
+	for _, tfRequirement := range tfRequirements {
+		requirementData := tfRequirement.(map[string]interface{})
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      requirementData["key"].(string),
+			Operator: corev1.NodeSelectorOperator(requirementData["operator"].(string)),
+			Values:   toStringSlice(requirementData["values"].([]interface{})),
+		})
+	}
+	return requirements
+}
+
+func getPodAffinityTerms(tfTerms []interface{}) []corev1.PodAffinityTerm {
+	var terms []corev1.PodAffinityTerm
+	for _, tfTerm := range tfTerms {
+		termData := tfTerm.(map[string]interface{})
+		terms = append(terms, corev1.PodAffinityTerm{
+			TopologyKey: termData["topology_key"].(string),
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: toStringMap(termData["match_labels"].(map[string]interface{})),
+			},
+			Namespaces: toStringSlice(termData["namespaces"].([]interface{})),
+		})
+	}
+	return terms
+}
+
+func getPodSecurityContext(tfSecurityContext []interface{}, rawSecurityContext cty.Value) *corev1.PodSecurityContext {
+	if len(tfSecurityContext) == 0 || tfSecurityContext[0] == nil {
+		return nil
+	}
+	contextData := tfSecurityContext[0].(map[string]interface{})
+	securityContext := &corev1.PodSecurityContext{}
+
+	if v := int64(contextData["run_as_user"].(int)); v != 0 || rawConfigSet(rawSecurityContext, 0, "run_as_user") {
+		securityContext.RunAsUser = &v
+	}
+	if v := int64(contextData["run_as_group"].(int)); v != 0 || rawConfigSet(rawSecurityContext, 0, "run_as_group") {
+		securityContext.RunAsGroup = &v
+	}
+	if v := contextData["run_as_non_root"].(bool); v {
+		securityContext.RunAsNonRoot = &v
+	}
+	if v := int64(contextData["fs_group"].(int)); v != 0 || rawConfigSet(rawSecurityContext, 0, "fs_group") {
+		securityContext.FSGroup = &v
+	}
+	for _, group := range contextData["supplemental_groups"].([]interface{}) {
+		securityContext.SupplementalGroups = append(securityContext.SupplementalGroups, int64(group.(int)))
+	}
+
+	return securityContext
+}
+
+func getLocalObjectReferences(tfNames []interface{}) []corev1.LocalObjectReference {
+	var references []corev1.LocalObjectReference
+	for _, name := range toStringSlice(tfNames) {
+		references = append(references, corev1.LocalObjectReference{Name: name})
+	}
+	return references
+}
+
+func getHostAliases(tfHostAliases []interface{}) []corev1.HostAlias {
+	var hostAliases []corev1.HostAlias
+	for _, tfHostAlias := range tfHostAliases {
+		aliasData := tfHostAlias.(map[string]interface{})
+		hostAliases = append(hostAliases, corev1.HostAlias{
+			IP:        aliasData["ip"].(string),
+			Hostnames: toStringSlice(aliasData["hostnames"].([]interface{})),
+		})
+	}
+	return hostAliases
+}
+
+func getPodDNSConfig(tfDNSConfig []interface{}) *corev1.PodDNSConfig {
+	if len(tfDNSConfig) == 0 || tfDNSConfig[0] == nil {
+		return nil
+	}
+	configData := tfDNSConfig[0].(map[string]interface{})
+	dnsConfig := &corev1.PodDNSConfig{
+		Nameservers: toStringSlice(configData["nameservers"].([]interface{})),
+		Searches:    toStringSlice(configData["searches"].([]interface{})),
+	}
+
+	for _, tfOption := range configData["options"].([]interface{}) {
+		optionData := tfOption.(map[string]interface{})
+		option := corev1.PodDNSConfigOption{
+			Name: optionData["name"].(string),
+		}
+		if v := optionData["value"].(string); v != "" {
+			option.Value = &v
+		}
+		dnsConfig.Options = append(dnsConfig.Options, option)
+	}
+
+	return dnsConfig
+}
+
+// envSchema defines the environment variables of a container.
+func envSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"value": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"secret_key_ref": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"key": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Helper function to convert Terraform env blocks into container environment variables
+func getEnvVars(tfEnv []interface{}) []corev1.EnvVar {
+	var env []corev1.EnvVar
+	for _, tfVar := range tfEnv {
+		varData := tfVar.(map[string]interface{})
+		envVar := corev1.EnvVar{
+			Name:  varData["name"].(string),
+			Value: varData["value"].(string),
+		}
+		if v := varData["secret_key_ref"].([]interface{}); len(v) > 0 && v[0] != nil {
+			refData := v[0].(map[string]interface{})
+			envVar.ValueFrom = &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: refData["name"].(string)},
+					Key:                  refData["key"].(string),
+				},
+			}
+		}
+		env = append(env, envVar)
+	}
+	return env
+}
+
+func flattenEnvVars(env []corev1.EnvVar) []interface{} {
+	var tfEnv []interface{}
+	for _, envVar := range env {
+		tfVar := map[string]interface{}{
+			"name":  envVar.Name,
+			"value": envVar.Value,
+		}
+		if envVar.ValueFrom != nil && envVar.ValueFrom.SecretKeyRef != nil {
+			tfVar["secret_key_ref"] = []interface{}{map[string]interface{}{
+				"name": envVar.ValueFrom.SecretKeyRef.Name,
+				"key":  envVar.ValueFrom.SecretKeyRef.Key,
+			}}
+		}
+		tfEnv = append(tfEnv, tfVar)
+	}
+	return tfEnv
+}
+
+func flattenTolerations(tolerations []corev1.Toleration) []interface{} {
+	var tfTolerations []interface{}
+	for _, toleration := range tolerations {
+		tfToleration := map[string]interface{}{
+			"key":      toleration.Key,
+			"operator": string(toleration.Operator),
+			"value":    toleration.Value,
+			"effect":   string(toleration.Effect),
+		}
+		if toleration.TolerationSeconds != nil {
+			tfToleration["toleration_seconds"] = int(*toleration.TolerationSeconds)
+		}
+		tfTolerations = append(tfTolerations, tfToleration)
+	}
+	return tfTolerations
+}
@@ -0,0 +1,130 @@
+package tekton
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestGetPodTemplate(t *testing.T) {
+	zero, thousand := int64(0), int64(1000)
+
+	tests := []struct {
+		name                string
+		config              map[string]interface{}
+		noRawConfig         bool
+		wantSecurityContext *corev1.PodSecurityContext
+		wantTolerations     []corev1.Toleration
+	}{
+		{
+			name:   "unset",
+			config: map[string]interface{}{"scheduler_name": "default-scheduler"},
+		},
+		{
+			name: "zero values",
+			config: map[string]interface{}{
+				"security_context": []interface{}{map[string]interface{}{
+					"run_as_user":  0,
+					"run_as_group": 0,
+					"fs_group":     0,
+				}},
+				"tolerations": []interface{}{map[string]interface{}{
+					"key":                "spot",
+					"operator":           "Exists",
+					"effect":             "NoExecute",
+					"toleration_seconds": 0,
+				}},
+			},
+			wantSecurityContext: &corev1.PodSecurityContext{RunAsUser: &zero, RunAsGroup: &zero, FSGroup: &zero},
+			wantTolerations:     []corev1.Toleration{{Key: "spot", Operator: "Exists", Effect: "NoExecute", TolerationSeconds: &zero}},
+		},
+		{
+			name: "non-zero values",
+			config: map[string]interface{}{
+				"security_context": []interface{}{map[string]interface{}{
+					"run_as_user":     1000,
+					"run_as_non_root": true,
+				}},
+				"tolerations": []interface{}{map[string]interface{}{
+					"key":                "spot",
+					"toleration_seconds": 1000,
+				}},
+			},
+			wantSecurityContext: &corev1.PodSecurityContext{RunAsUser: &thousand, RunAsNonRoot: boolPtr(true)},
+			wantTolerations:     []corev1.Toleration{{Key: "spot", TolerationSeconds: &thousand}},
+		},
+		{
+			name: "omitted values",
+			config: map[string]interface{}{
+				"security_context": []interface{}{map[string]interface{}{
+					"run_as_non_root": true,
+				}},
+				"tolerations": []interface{}{map[string]interface{}{
+					"key": "spot",
+				}},
+			},
+			wantSecurityContext: &corev1.PodSecurityContext{RunAsNonRoot: boolPtr(true)},
+			wantTolerations:     []corev1.Toleration{{Key: "spot"}},
+		},
+		{
+			name: "zero values without a raw config",
+			config: map[string]interface{}{
+				"security_context": []interface{}{map[string]interface{}{
+					"run_as_user": 0,
+				}},
+				"tolerations": []interface{}{map[string]interface{}{
+					"key":                "spot",
+					"toleration_seconds": 0,
+				}},
+			},
+			noRawConfig:         true,
+			wantSecurityContext: &corev1.PodSecurityContext{},
+			wantTolerations:     []corev1.Toleration{{Key: "spot"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := map[string]*schema.Schema{"pod_template": podTemplateSchema()}
+			config := map[string]interface{}{"pod_template": []interface{}{tt.config}}
+			d := schema.TestResourceDataRaw(t, s, config)
+			raw := cty.NilVal
+			if !tt.noRawConfig {
+				raw = testRawConfig(t, s, config).GetAttr("pod_template")
+			}
+
+			template := getPodTemplate(d.Get("pod_template").([]interface{}), raw)
+			if template == nil {
+				t.Fatalf("getPodTemplate() = nil")
+			}
+			if !reflect.DeepEqual(template.SecurityContext, tt.wantSecurityContext) {
+				t.Errorf("SecurityContext = %+v, want %+v", template.SecurityContext, tt.wantSecurityContext)
+			}
+			if !reflect.DeepEqual(template.Tolerations, tt.wantTolerations) {
+				t.Errorf("Tolerations = %+v, want %+v", template.Tolerations, tt.wantTolerations)
+			}
+		})
+	}
+}
+
+// testRawConfig returns config as the raw configuration Terraform passes for a schema.
+func testRawConfig(t *testing.T, s map[string]*schema.Schema, config map[string]interface{}) cty.Value {
+	t.Helper()
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("invalid test config: %v", err)
+	}
+	value, err := ctyjson.Unmarshal(data, (&schema.Resource{Schema: s}).CoreConfigSchema().ImpliedType())
+	if err != nil {
+		t.Fatalf("invalid test config %s: %v", data, err)
+	}
+	return value
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
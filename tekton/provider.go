@@ -1,15 +1,24 @@
 package tekton
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	triggersclient "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -19,30 +28,109 @@ func Provider() *schema.Provider {
 		Schema: map[string]*schema.Schema{
 			"kubeconfig": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("KUBECONFIG", nil),
-				Description: "Path to the Kubernetes configuration file.",
+				Description: "Path to the Kubernetes configuration file. Only the offline tekton_trigger_render data source works without one.",
+			},
+			"default_labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Labels added to every object managed by the provider. Labels set on a resource take precedence.",
+			},
+			"ignore_labels": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Regular expressions of label keys, e.g. added by controllers, that are not reported as drift.",
+			},
+			"ignore_annotations": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Regular expressions of annotation keys, e.g. added by controllers, that are not reported as drift.",
+			},
+			"field_manager": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "terraform-provider-tekton",
+				Description: "The field manager name used for server-side apply. Only fields owned by this manager are checked for drift.",
+			},
+			"force_conflicts": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Take ownership of fields owned by other field managers instead of failing on conflicts.",
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"tekton_task":            resourceTektonTask(),
-			"tekton_taskrun":         resourceTektonTaskRun(),
-			"tekton_pipeline":        resourceTektonPipeline(),
-			"tekton_pipelinerun":     resourceTektonPipelineRun(),
-			"tekton_triggertemplate": resourceTektonTriggerTemplate(),
-			"tekton_triggerbinding":  resourceTektonTriggerBinding(),
-			"tekton_eventlistener":   resourceTektonEventListener(),
+			"tekton_task":                  requireCluster(resourceTektonTask()),
+			"tekton_clustertask":           requireCluster(resourceTektonClusterTask()),
+			"tekton_stepaction":            requireCluster(resourceTektonStepAction()),
+			"tekton_taskrun":               requireCluster(resourceTektonTaskRun()),
+			"tekton_pipeline":              requireCluster(resourceTektonPipeline()),
+			"tekton_pipelinerun":           requireCluster(resourceTektonPipelineRun()),
+			"tekton_customrun":             requireCluster(resourceTektonCustomRun()),
+			"tekton_triggertemplate":       requireCluster(resourceTektonTriggerTemplate()),
+			"tekton_triggerbinding":        requireCluster(resourceTektonTriggerBinding()),
+			"tekton_eventlistener":         requireCluster(resourceTektonEventListener()),
+			"tekton_trigger":               requireCluster(resourceTektonTrigger()),
+			"tekton_clustertriggerbinding": requireCluster(resourceTektonClusterTriggerBinding()),
+			"tekton_interceptor":           requireCluster(resourceTektonInterceptor()),
+			"tekton_clusterinterceptor":    requireCluster(resourceTektonClusterInterceptor()),
+			"tekton_verification_policy":   requireCluster(resourceTektonVerificationPolicy()),
+			"tekton_manifest":              requireCluster(resourceTektonManifest()),
+			"tekton_config":                requireCluster(resourceTektonConfig()),
 			// Define other resources like "tekton_pipeline" here
 		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"tekton_task":           requireCluster(dataSourceTektonTask()),
+			"tekton_clustertask":    requireCluster(dataSourceTektonClusterTask()),
+			"tekton_pipeline":       requireCluster(dataSourceTektonPipeline()),
+			"tekton_tasks":          requireCluster(dataSourceTektonTasks()),
+			"tekton_pipelines":      requireCluster(dataSourceTektonPipelines()),
+			"tekton_taskruns":       requireCluster(dataSourceTektonTaskRuns()),
+			"tekton_pipelineruns":   requireCluster(dataSourceTektonPipelineRuns()),
+			"tekton_trigger_render": dataSourceTektonTriggerRender(),
+		},
 		ConfigureFunc: providerConfigure,
 	}
 }
 
+// providerClients holds the clients shared by all resources.
+type providerClients struct {
+	TektonClient         tektonclient.Interface
+	TektonTriggersClient triggersclient.Interface
+	KubeClient           kubernetes.Interface
+	DynamicClient        dynamic.Interface
+	RESTMapper           meta.RESTMapper
+	Metadata             metadataConfig
+	FieldManager         string
+	ForceConflicts       bool
+	// ConfigError is why the Kubernetes clients could not be set up, if they could not. Only
+	// offline data sources can be used then.
+	ConfigError error
+}
+
 // providerConfigure sets up the Tekton client for interacting with Tekton resources.
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	configPath := d.Get("kubeconfig").(string)
+	metadata, err := getMetadataConfig(d)
+	if err != nil {
+		return nil, err
+	}
 
-	kubeConfig, err := loadKubeConfig(configPath)
+	clients := providerClients{
+		Metadata:       metadata,
+		FieldManager:   d.Get("field_manager").(string),
+		ForceConflicts: d.Get("force_conflicts").(bool),
+	}
+
+	kubeConfig, err := loadKubeConfig(d.Get("kubeconfig").(string))
+	if errors.Is(err, errNoKubeConfig) {
+		// Offline data sources still work; everything else reports the error when it is used.
+		clients.ConfigError = err
+		return clients, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -57,23 +145,82 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		return nil, err
 	}
 
-	return struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	}{
-		TektonClient:         tektonClient,
-		TektonTriggersClient: tektonTriggersClient,
-	}, nil
+	kubeClient, err := kubernetes.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(kubeConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	clients.TektonClient = tektonClient
+	clients.TektonTriggersClient = tektonTriggersClient
+	clients.KubeClient = kubeClient
+	clients.DynamicClient = dynamicClient
+	clients.RESTMapper = restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(kubeClient.Discovery()))
+	return clients, nil
+}
+
+// requireCluster makes the operations of a resource or data source fail with the provider's
+// configuration error when no Kubernetes clients could be set up.
+func requireCluster(r *schema.Resource) *schema.Resource {
+	wrap := func(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+		if f == nil {
+			return nil
+		}
+		return func(d *schema.ResourceData, m interface{}) error {
+			if err := checkClusterConfigured(m); err != nil {
+				return err
+			}
+			return f(d, m)
+		}
+	}
+	r.Create = wrap(r.Create)
+	r.Read = wrap(r.Read)
+	r.Update = wrap(r.Update)
+	r.Delete = wrap(r.Delete)
+
+	if customizeDiff := r.CustomizeDiff; customizeDiff != nil {
+		r.CustomizeDiff = func(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+			if err := checkClusterConfigured(m); err != nil {
+				return err
+			}
+			return customizeDiff(ctx, d, m)
+		}
+	}
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		importState := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			if err := checkClusterConfigured(m); err != nil {
+				return nil, err
+			}
+			return importState(ctx, d, m)
+		}
+	}
+	return r
 }
 
+// checkClusterConfigured returns the provider's configuration error, if any.
+func checkClusterConfigured(m interface{}) error {
+	if clients, ok := m.(providerClients); ok && clients.ConfigError != nil {
+		return fmt.Errorf("the provider has no usable Kubernetes configuration: %v", clients.ConfigError)
+	}
+	return nil
+}
+
+// errNoKubeConfig is returned by loadKubeConfig when no configuration file is set.
+var errNoKubeConfig = errors.New("KUBECONFIG environment variable or kubeconfig file must be provided")
+
 // loadKubeConfig loads the Kubernetes configuration from a file.
 func loadKubeConfig(configPath string) (*rest.Config, error) {
 	if configPath == "" {
-		return nil, fmt.Errorf("KUBECONFIG environment variable or kubeconfig file must be provided")
+		return nil, errNoKubeConfig
 	}
 
 	// Expand "~" to the user's home directory
-	if configPath[:2] == "~/" {
+	if strings.HasPrefix(configPath, "~/") {
 		homeDir, err := os.UserHomeDir()
 		if err != nil {
 			return nil, fmt.Errorf("failed to get home directory: %v", err)
@@ -84,3 +231,72 @@ func loadKubeConfig(configPath string) (*rest.Config, error) {
 	// Build the Kubernetes configuration from the file
 	return clientcmd.BuildConfigFromFlags("", configPath)
 }
+
+// mergeSchemas combines schema maps into a new map, later maps overriding earlier ones.
+func mergeSchemas(schemas ...map[string]*schema.Schema) map[string]*schema.Schema {
+	merged := map[string]*schema.Schema{}
+	for _, s := range schemas {
+		for k, v := range s {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// forceNewSchemas marks every configurable field, including fields of nested blocks, as ForceNew.
+func forceNewSchemas(schemas map[string]*schema.Schema) map[string]*schema.Schema {
+	for _, s := range schemas {
+		if s.Computed && !s.Optional {
+			continue
+		}
+		s.ForceNew = true
+		if r, ok := s.Elem.(*schema.Resource); ok {
+			forceNewSchemas(r.Schema)
+		}
+	}
+	return schemas
+}
+
+// rawPlanKnown reports whether the planned values of the given top level attributes are wholly known.
+func rawPlanKnown(d *schema.ResourceDiff, keys ...string) bool {
+	plan := d.GetRawPlan()
+	if plan.IsNull() || !plan.IsKnown() {
+		return false
+	}
+	for _, key := range keys {
+		if !plan.GetAttr(key).IsWhollyKnown() {
+			return false
+		}
+	}
+	return true
+}
+
+// rawConfigAt returns the raw config value at path, where each step of the path is an attribute
+// name or a list index, or cty.NilVal if the path does not exist.
+func rawConfigAt(value cty.Value, path ...interface{}) cty.Value {
+	for _, step := range path {
+		if value == cty.NilVal || value.IsNull() || !value.IsKnown() {
+			return cty.NilVal
+		}
+		switch step := step.(type) {
+		case string:
+			if !value.Type().IsObjectType() || !value.Type().HasAttribute(step) {
+				return cty.NilVal
+			}
+			value = value.GetAttr(step)
+		case int:
+			if !value.CanIterateElements() || step >= value.LengthInt() {
+				return cty.NilVal
+			}
+			value = value.Index(cty.NumberIntVal(int64(step)))
+		}
+	}
+	return value
+}
+
+// rawConfigSet reports whether the attribute at path is set in the raw config. It tells an
+// attribute explicitly set to its zero value apart from one that is not set at all.
+func rawConfigSet(value cty.Value, path ...interface{}) bool {
+	value = rawConfigAt(value, path...)
+	return value != cty.NilVal && !value.IsNull()
+}
@@ -0,0 +1,74 @@
+package tekton
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProviderConfigure(t *testing.T) {
+	dir := t.TempDir()
+	malformed := filepath.Join(dir, "malformed")
+	if err := os.WriteFile(malformed, []byte("clusters: ["), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	valid := filepath.Join(dir, "valid")
+	config := `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://127.0.0.1:6443
+contexts:
+- name: test
+  context:
+    cluster: test
+current-context: test
+`
+	if err := os.WriteFile(valid, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		kubeconfig      string
+		wantErr         bool
+		wantConfigError bool
+	}{
+		{name: "no kubeconfig", wantConfigError: true},
+		{name: "valid file", kubeconfig: valid},
+		{name: "missing file", kubeconfig: filepath.Join(dir, "missing"), wantErr: true},
+		{name: "malformed file", kubeconfig: malformed, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("KUBECONFIG", "")
+			d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"kubeconfig": tt.kubeconfig})
+
+			meta, err := providerConfigure(d)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("providerConfigure() error = nil, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("providerConfigure() error = %v", err)
+			}
+
+			clients := meta.(providerClients)
+			if tt.wantConfigError {
+				if clients.ConfigError == nil || !strings.Contains(clients.ConfigError.Error(), "kubeconfig") {
+					t.Errorf("ConfigError = %v, want the missing kubeconfig error", clients.ConfigError)
+				}
+				return
+			}
+			if clients.ConfigError != nil || clients.TektonClient == nil {
+				t.Errorf("ConfigError = %v, want configured clients", clients.ConfigError)
+			}
+		})
+	}
+}
@@ -4,11 +4,12 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
-	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
-	triggersclient "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // resourceTektonTask defines a Tekton Task.
@@ -19,50 +20,83 @@ func resourceTektonTask() *schema.Resource {
 		Update: resourceTektonTaskUpdate,
 		Delete: resourceTektonTaskDelete,
 
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: customdiff.Sequence(customizeTaskDiff, customizeLabelsDiff),
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"namespace": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "default",
+				ForceNew: true,
 			},
-			"steps": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"image": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"command": {
-							Type:     schema.TypeList,
-							Required: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
+		}, taskSpecSchema(), metadataSchema()),
+	}
+}
+
+// taskSpecSchema defines the fields of a Task spec, shared with inline task_spec blocks.
+func taskSpecSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"steps": {
+			Type:     schema.TypeList,
+			Required: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"image": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The image of the step. Exactly one of image or ref must be set.",
+					},
+					"command": {
+						Type:     schema.TypeList,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+					"ref": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "A StepAction to run in place of image and command.",
+						Elem: &schema.Resource{
+							Schema: map[string]*schema.Schema{
+								"name": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "The name of a StepAction in the Task's namespace.",
+								},
+								"resolver": {
+									Type:        schema.TypeString,
+									Optional:    true,
+									Description: "The resolver of a remote StepAction, e.g. git or bundles.",
+								},
+								"params": stepParamsSchema("The params of the resolver."),
+							},
 						},
 					},
+					"params": stepParamsSchema("The params passed to the referenced StepAction."),
 				},
 			},
-			"workspaces": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"description": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+		},
+		"workspaces": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"description": {
+						Type:     schema.TypeString,
+						Optional: true,
 					},
 				},
 			},
@@ -91,28 +125,28 @@ func getTaskWorkspaces(tfWorkspaces []interface{}) []tektonv1beta1.WorkspaceDecl
 
 // resourceTektonTaskCreate creates a Tekton Task.
 func resourceTektonTaskCreate(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Get("name").(string)
 	namespace := d.Get("namespace").(string)
 
-	steps := getTaskSteps(d.Get("steps").([]interface{}))
-	workspaces := getTaskWorkspaces(d.Get("workspaces").([]interface{}))
+	spec, err := getTaskSpec(d.Get("steps").([]interface{}), d.Get("workspaces").([]interface{}))
+	if err != nil {
+		return err
+	}
 
 	task := &tektonv1beta1.Task{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: tektonv1beta1.TaskSpec{
-			Steps:      steps,
-			Workspaces: workspaces,
-		},
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       spec,
+	}
+
+	if _, err := clients.TektonClient.TektonV1beta1().Tasks(namespace).Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "Task", namespace, name)
 	}
 
-	_, err := clients.TektonClient.TektonV1beta1().Tasks(namespace).Create(context.Background(), task, metav1.CreateOptions{})
+	patch, err := getApplyPatch(task)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1beta1().Tasks(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create Tekton Task: %v", err)
 	}
@@ -122,20 +156,49 @@ func resourceTektonTaskCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceTektonTaskRead(d *schema.ResourceData, m interface{}) error {
-	// Implement read logic
-	return nil
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	task, err := clients.TektonClient.TektonV1beta1().Tasks(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	setObjectMeta(clients, d, task.ObjectMeta)
+
+	return setManagedTaskSpec(clients, d, task.ObjectMeta, task.Spec)
 }
 
 func resourceTektonTaskUpdate(d *schema.ResourceData, m interface{}) error {
-	// Implement update logic
-	return nil
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	spec, err := getTaskSpec(d.Get("steps").([]interface{}), d.Get("workspaces").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	task := &tektonv1beta1.Task{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       spec,
+	}
+
+	patch, err := getApplyPatch(task)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1beta1().Tasks(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton Task: %v", err)
+	}
+
+	return resourceTektonTaskRead(d, m)
 }
 
 func resourceTektonTaskDelete(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
@@ -148,21 +211,162 @@ func resourceTektonTaskDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// Helper function to build a Tekton task spec from Terraform steps and workspaces
+func getTaskSpec(tfSteps []interface{}, tfWorkspaces []interface{}) (tektonv1beta1.TaskSpec, error) {
+	steps, err := getTaskSteps(tfSteps)
+	if err != nil {
+		return tektonv1beta1.TaskSpec{}, err
+	}
+	return tektonv1beta1.TaskSpec{
+		Steps:      steps,
+		Workspaces: getTaskWorkspaces(tfWorkspaces),
+	}, nil
+}
+
+// setManagedTaskSpec sets the steps and workspaces of a Task or ClusterTask from the fields the
+// provider's field manager owns.
+func setManagedTaskSpec(clients providerClients, d *schema.ResourceData, meta metav1.ObjectMeta, spec tektonv1beta1.TaskSpec) error {
+	steps, err := flattenTaskSteps(spec.Steps)
+	if err != nil {
+		return err
+	}
+	setManagedField(clients, d, meta, "steps", steps, "spec", "steps")
+	setManagedField(clients, d, meta, "workspaces", flattenTaskWorkspaces(spec.Workspaces), "spec", "workspaces")
+	return nil
+}
+
+// customizeTaskDiff checks at plan time that every step of a Task or ClusterTask has exactly one
+// of image or ref.
+func customizeTaskDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !rawPlanKnown(d, "steps") {
+		return nil
+	}
+	_, err := getTaskSteps(d.Get("steps").([]interface{}))
+	return err
+}
+
 // Helper function to convert Terraform steps to Tekton steps
-func getTaskSteps(tfSteps []interface{}) []tektonv1beta1.Step {
+func getTaskSteps(tfSteps []interface{}) ([]tektonv1beta1.Step, error) {
 	var steps []tektonv1beta1.Step
 
-	for _, tfStep := range tfSteps {
+	for i, tfStep := range tfSteps {
 		stepData := tfStep.(map[string]interface{})
 		step := tektonv1beta1.Step{
 			Name:    stepData["name"].(string),
 			Image:   stepData["image"].(string),
 			Command: toStringSlice(stepData["command"].([]interface{})),
+			Params:  getStepParams(stepData["params"].([]interface{})),
+		}
+		if v := stepData["ref"].([]interface{}); len(v) > 0 && v[0] != nil {
+			refData := v[0].(map[string]interface{})
+			step.Ref = &tektonv1beta1.Ref{
+				Name: refData["name"].(string),
+				ResolverRef: tektonv1beta1.ResolverRef{
+					Resolver: tektonv1beta1.ResolverName(refData["resolver"].(string)),
+					Params:   getStepParams(refData["params"].([]interface{})),
+				},
+			}
+		}
+
+		switch {
+		case step.Image != "" && step.Ref != nil:
+			return nil, fmt.Errorf("steps.%d: image and ref are mutually exclusive", i)
+		case step.Image == "" && step.Ref == nil:
+			return nil, fmt.Errorf("steps.%d: one of image or ref must be set", i)
 		}
 		steps = append(steps, step)
 	}
 
-	return steps
+	return steps, nil
+}
+
+func flattenTaskSteps(steps []tektonv1beta1.Step) ([]interface{}, error) {
+	var tfSteps []interface{}
+	for _, step := range steps {
+		params, err := flattenStepParams(step.Params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the params of step %s: %v", step.Name, err)
+		}
+		tfStep := map[string]interface{}{
+			"name":    step.Name,
+			"image":   step.Image,
+			"command": step.Command,
+			"params":  params,
+		}
+		if step.Ref != nil {
+			refParams, err := flattenStepParams(step.Ref.Params)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode the resolver params of step %s: %v", step.Name, err)
+			}
+			tfStep["ref"] = []interface{}{map[string]interface{}{
+				"name":     step.Ref.Name,
+				"resolver": string(step.Ref.Resolver),
+				"params":   refParams,
+			}}
+		}
+		tfSteps = append(tfSteps, tfStep)
+	}
+	return tfSteps, nil
+}
+
+func flattenTaskWorkspaces(workspaces []tektonv1beta1.WorkspaceDeclaration) []interface{} {
+	var tfWorkspaces []interface{}
+	for _, workspace := range workspaces {
+		tfWorkspaces = append(tfWorkspaces, map[string]interface{}{
+			"name":        workspace.Name,
+			"description": workspace.Description,
+		})
+	}
+	return tfWorkspaces
+}
+
+// stepParamsSchema defines string params given to a step action or resolver.
+func stepParamsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"value": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+			},
+		},
+	}
+}
+
+// Helper function to convert Terraform step params into Tekton params
+func getStepParams(tfParams []interface{}) tektonv1beta1.Params {
+	var params tektonv1beta1.Params
+	for _, tfParam := range tfParams {
+		paramData := tfParam.(map[string]interface{})
+		params = append(params, tektonv1beta1.Param{
+			Name:  paramData["name"].(string),
+			Value: *tektonv1beta1.NewStructuredValues(paramData["value"].(string)),
+		})
+	}
+	return params
+}
+
+func flattenStepParams(params tektonv1beta1.Params) ([]interface{}, error) {
+	var tfParams []interface{}
+	for _, param := range params {
+		value, err := flattenParamValue(&param.Value)
+		if err != nil {
+			return nil, err
+		}
+		tfParams = append(tfParams, map[string]interface{}{
+			"name":  param.Name,
+			"value": value,
+		})
+	}
+	return tfParams, nil
 }
 
 func toStringSlice(tfList []interface{}) []string {
@@ -172,3 +376,14 @@ func toStringSlice(tfList []interface{}) []string {
 	}
 	return result
 }
+
+func toStringMap(tfMap map[string]interface{}) map[string]string {
+	if len(tfMap) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(tfMap))
+	for k, v := range tfMap {
+		result[k] = v.(string)
+	}
+	return result
+}
@@ -3,11 +3,11 @@ package tekton
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
-	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
-	triggersclient "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -19,20 +19,31 @@ func resourceTektonTaskRun() *schema.Resource {
 		Update: resourceTektonTaskRunUpdate,
 		Delete: resourceTektonTaskRunDelete,
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
-			},
+		CustomizeDiff: customizeRunDiff,
+
+		Schema: mergeSchemas(runTriggerSchema(), runDestroySchema(), map[string]*schema.Schema{
+			"capture_logs": captureLogsSchema(),
+		}, forceNewSchemas(map[string]*schema.Schema{
 			"namespace": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "default",
 			},
 			"task_ref_name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the Tekton Task to run",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"task_ref_name", "task_spec"},
+				Description:  "The name of the Tekton Task to run",
+			},
+			"task_spec": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				ExactlyOneOf: []string{"task_ref_name", "task_spec"},
+				Description:  "An inline Task definition to run instead of referencing one.",
+				Elem: &schema.Resource{
+					Schema: taskSpecSchema(),
+				},
 			},
 			"params": {
 				Type:     schema.TypeList,
@@ -55,52 +66,43 @@ func resourceTektonTaskRun() *schema.Resource {
 				Optional: true,
 				Default:  "default",
 			},
-		},
+			"timeout":           durationSchema("Timeout for the TaskRun, e.g. \"1h\"."),
+			"pod_template":      podTemplateSchema(),
+			"compute_resources": computeResourcesSchema(),
+		}), forceNewSchemas(runMetadataSchema())),
 	}
 }
 
 // resourceTektonTaskRunCreate creates a Tekton TaskRun.
 func resourceTektonTaskRunCreate(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
-	name := d.Get("name").(string)
+	clients := m.(providerClients)
 	namespace := d.Get("namespace").(string)
-	taskRefName := d.Get("task_ref_name").(string)
-	serviceAccountName := d.Get("service_account_name").(string)
-
-	params := getTaskRunParams(d.Get("params").([]interface{}))
 
-	taskRun := &tektonv1beta1.TaskRun{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: tektonv1beta1.TaskRunSpec{
-			TaskRef: &tektonv1beta1.TaskRef{
-				Name: taskRefName,
-			},
-			ServiceAccountName: serviceAccountName,
-			Params:             params,
-		},
+	taskRun, err := getTaskRun(clients.Metadata, d)
+	if err != nil {
+		return err
 	}
 
-	_, err := clients.TektonClient.TektonV1beta1().TaskRuns(namespace).Create(context.Background(), taskRun, metav1.CreateOptions{})
+	created, err := clients.TektonClient.TektonV1beta1().TaskRuns(namespace).Create(context.Background(), taskRun, metav1.CreateOptions{FieldManager: clients.FieldManager})
 	if err != nil {
 		return fmt.Errorf("failed to create Tekton TaskRun: %v", err)
 	}
 
-	d.SetId(name)
+	d.SetId(created.Name)
+	d.Set("name", created.Name)
+
+	if config := getCaptureLogsConfig(d.Get("capture_logs").([]interface{})); config != nil {
+		if err := waitForTaskRunLogs(clients, namespace, created.Name, config); err != nil {
+			return err
+		}
+	}
+
 	return resourceTektonTaskRunRead(d, m)
 }
 
 // resourceTektonTaskRunRead reads the state of a Tekton TaskRun.
 func resourceTektonTaskRunRead(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
@@ -116,28 +118,98 @@ func resourceTektonTaskRunRead(d *schema.ResourceData, m interface{}) error {
 
 // resourceTektonTaskRunUpdate updates a Tekton TaskRun (if necessary).
 func resourceTektonTaskRunUpdate(d *schema.ResourceData, m interface{}) error {
-	// TaskRuns are typically immutable after creation, so you may want to handle this accordingly
-	return resourceTektonTaskRunRead(d, m)
+	// TaskRuns are immutable after creation. In generate_name mode a change of
+	// triggers starts a fresh run instead of replacing the resource.
+	if !d.HasChange("triggers") {
+		return resourceTektonTaskRunRead(d, m)
+	}
+
+	clients := m.(providerClients)
+	previous := d.Id()
+
+	if err := resourceTektonTaskRunCreate(d, m); err != nil {
+		return err
+	}
+
+	if !d.Get("keep_previous_runs").(bool) {
+		if err := destroyTektonTaskRun(clients, d, previous); err != nil {
+			return fmt.Errorf("failed to clean up previous Tekton TaskRun %s: %v", previous, err)
+		}
+	}
+
+	return nil
 }
 
 // resourceTektonTaskRunDelete deletes a Tekton TaskRun.
 func resourceTektonTaskRunDelete(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
-	name := d.Id()
+	clients := m.(providerClients)
+
+	if err := destroyTektonTaskRun(clients, d, d.Id()); err != nil {
+		return err
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// destroyTektonTaskRun deletes, cancels or keeps a TaskRun according to on_destroy. It is
+// used both on destroy and for the previous run when triggers start a new one.
+func destroyTektonTaskRun(clients providerClients, d *schema.ResourceData, name string) error {
 	namespace := d.Get("namespace").(string)
 
+	switch d.Get("on_destroy").(string) {
+	case onDestroyKeep:
+		return nil
+	case onDestroyCancel:
+		timeout, _ := time.ParseDuration(d.Get("cancel_timeout").(string))
+		return cancelTaskRun(clients, namespace, name, timeout)
+	}
+
 	err := clients.TektonClient.TektonV1beta1().TaskRuns(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
-	if err != nil {
+	if err != nil && !errors.IsNotFound(err) {
 		return fmt.Errorf("failed to delete Tekton TaskRun: %v", err)
 	}
-
-	d.SetId("")
 	return nil
 }
 
+// Helper function to build a Tekton TaskRun from the resource configuration
+func getTaskRun(config metadataConfig, d *schema.ResourceData) (*tektonv1beta1.TaskRun, error) {
+	timeout, err := parseDuration(d.Get("timeout").(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout: %v", err)
+	}
+	computeResources, err := getComputeResources(d.Get("compute_resources").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	taskRun := &tektonv1beta1.TaskRun{
+		ObjectMeta: getRunObjectMeta(config, d),
+		Spec: tektonv1beta1.TaskRunSpec{
+			ServiceAccountName: d.Get("service_account_name").(string),
+			Params:             getTaskRunParams(d.Get("params").([]interface{})),
+			Timeout:            timeout,
+			PodTemplate:        getPodTemplate(d.Get("pod_template").([]interface{}), d.GetRawConfig().GetAttr("pod_template")),
+			ComputeResources:   computeResources,
+		},
+	}
+
+	if v, ok := d.GetOk("task_spec"); ok {
+		specData := v.([]interface{})[0].(map[string]interface{})
+		taskSpec, err := getTaskSpec(specData["steps"].([]interface{}), specData["workspaces"].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("task_spec: %v", err)
+		}
+		taskRun.Spec.TaskSpec = &taskSpec
+	} else {
+		taskRun.Spec.TaskRef = &tektonv1beta1.TaskRef{
+			Name: d.Get("task_ref_name").(string),
+		}
+	}
+
+	return taskRun, nil
+}
+
 // Helper function to convert Terraform params to Tekton params
 func getTaskRunParams(tfParams []interface{}) []tektonv1beta1.Param {
 	var params []tektonv1beta1.Param
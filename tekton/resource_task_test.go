@@ -0,0 +1,145 @@
+package tekton
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonfake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGetTaskSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		steps   []interface{}
+		want    []tektonv1beta1.Step
+		wantErr string
+	}{
+		{
+			name: "image only",
+			steps: []interface{}{map[string]interface{}{
+				"name":    "build",
+				"image":   "golang",
+				"command": []interface{}{"go", "build"},
+			}},
+			want: []tektonv1beta1.Step{{Name: "build", Image: "golang", Command: []string{"go", "build"}}},
+		},
+		{
+			name: "ref only",
+			steps: []interface{}{map[string]interface{}{
+				"name": "clone",
+				"ref": []interface{}{map[string]interface{}{
+					"resolver": "git",
+					"params": []interface{}{
+						map[string]interface{}{"name": "url", "value": "https://github.com/tektoncd/catalog"},
+						map[string]interface{}{"name": "pathInRepo", "value": "stepaction/git-clone/git-clone.yaml"},
+					},
+				}},
+				"params": []interface{}{
+					map[string]interface{}{"name": "revision", "value": "main"},
+				},
+			}},
+			want: []tektonv1beta1.Step{{
+				Name: "clone",
+				Ref: &tektonv1beta1.Ref{
+					ResolverRef: tektonv1beta1.ResolverRef{
+						Resolver: "git",
+						Params: tektonv1beta1.Params{
+							{Name: "url", Value: *tektonv1beta1.NewStructuredValues("https://github.com/tektoncd/catalog")},
+							{Name: "pathInRepo", Value: *tektonv1beta1.NewStructuredValues("stepaction/git-clone/git-clone.yaml")},
+						},
+					},
+				},
+				Params: tektonv1beta1.Params{
+					{Name: "revision", Value: *tektonv1beta1.NewStructuredValues("main")},
+				},
+			}},
+		},
+		{
+			name: "local ref",
+			steps: []interface{}{map[string]interface{}{
+				"name": "clone",
+				"ref":  []interface{}{map[string]interface{}{"name": "git-clone"}},
+			}},
+			want: []tektonv1beta1.Step{{Name: "clone", Ref: &tektonv1beta1.Ref{Name: "git-clone"}}},
+		},
+		{
+			name: "image and ref",
+			steps: []interface{}{map[string]interface{}{
+				"name":  "clone",
+				"image": "alpine/git",
+				"ref":   []interface{}{map[string]interface{}{"name": "git-clone"}},
+			}},
+			wantErr: "steps.0: image and ref are mutually exclusive",
+		},
+		{
+			name: "neither image nor ref",
+			steps: []interface{}{
+				map[string]interface{}{"name": "build", "image": "golang"},
+				map[string]interface{}{"name": "test"},
+			},
+			wantErr: "steps.1: one of image or ref must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceTektonTask().Schema, map[string]interface{}{
+				"name":  "build",
+				"steps": tt.steps,
+			})
+
+			steps, err := getTaskSteps(d.Get("steps").([]interface{}))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("getTaskSteps() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getTaskSteps() error = %v", err)
+			}
+			if !reflect.DeepEqual(steps, tt.want) {
+				t.Errorf("getTaskSteps() = %+v, want %+v", steps, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceTektonTaskRead(t *testing.T) {
+	d := testResourceData(t, resourceTektonTask(), map[string]interface{}{
+		"name":      "build",
+		"namespace": "ci",
+		"steps": []interface{}{
+			map[string]interface{}{
+				"name":    "compile",
+				"image":   "golang",
+				"command": []interface{}{"go", "build"},
+			},
+			map[string]interface{}{
+				"name": "clone",
+				"ref": []interface{}{map[string]interface{}{
+					"resolver": "git",
+					"params":   []interface{}{map[string]interface{}{"name": "url", "value": "https://example.com/repo.git"}},
+				}},
+				"params": []interface{}{map[string]interface{}{"name": "revision", "value": "main"}},
+			},
+		},
+		"workspaces": []interface{}{map[string]interface{}{"name": "source", "description": "The checked out sources."}},
+	})
+	spec, err := getTaskSpec(d.Get("steps").([]interface{}), d.Get("workspaces").([]interface{}))
+	if err != nil {
+		t.Fatalf("getTaskSpec() error = %v", err)
+	}
+	d.SetId("build")
+
+	clients := providerClients{
+		TektonClient: tektonfake.NewSimpleClientset(&tektonv1beta1.Task{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "ci"},
+			Spec:       spec,
+		}),
+		FieldManager: "terraform",
+	}
+	testReadKeepsConfig(t, d, resourceTektonTaskRead, clients, "steps", "workspaces")
+}
@@ -0,0 +1,166 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	onDestroyDelete = "delete"
+	onDestroyCancel = "cancel"
+	onDestroyKeep   = "keep"
+)
+
+// runDestroySchema defines how a run is cleaned up when it is destroyed or replaced.
+func runDestroySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"on_destroy": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      onDestroyDelete,
+			ValidateFunc: validation.StringInSlice([]string{onDestroyDelete, onDestroyCancel, onDestroyKeep}, false),
+			Description:  "What to do with the run on destroy: \"delete\" it, \"cancel\" it, wait for it to settle and leave it in the cluster, or \"keep\" it in the cluster as it is. Both cancel and keep only remove the run from state.",
+		},
+		"cancel_timeout": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "10m",
+			ValidateFunc: validateDuration,
+			Description:  "How long to wait for a cancelled run to settle when on_destroy is \"cancel\".",
+		},
+	}
+}
+
+// pipelineRunCancelStatusSchema defines the spec.status used to cancel a PipelineRun.
+func pipelineRunCancelStatusSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Default:  tektonv1beta1.PipelineRunSpecStatusCancelledRunFinally,
+		ValidateFunc: validation.StringInSlice([]string{
+			tektonv1beta1.PipelineRunSpecStatusCancelled,
+			tektonv1beta1.PipelineRunSpecStatusCancelledRunFinally,
+			tektonv1beta1.PipelineRunSpecStatusStoppedRunFinally,
+		}, false),
+		Description: "The spec.status patched onto the PipelineRun when on_destroy is \"cancel\".",
+	}
+}
+
+// patchRunSpecStatus sets spec.status on a PipelineRun, TaskRun or CustomRun with a merge patch.
+func patchRunSpecStatus(status string) []byte {
+	return []byte(fmt.Sprintf(`{"spec":{"status":%q}}`, status))
+}
+
+// cancelPipelineRun patches a PipelineRun to the given cancel status and waits until it is done.
+func cancelPipelineRun(clients providerClients, namespace, name, status string, timeout time.Duration) error {
+	pipelineRuns := clients.TektonClient.TektonV1beta1().PipelineRuns(namespace)
+
+	pipelineRun, err := pipelineRuns.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get Tekton PipelineRun: %v", err)
+	}
+	if pipelineRun.IsDone() {
+		return nil
+	}
+
+	_, err = pipelineRuns.Patch(context.Background(), name, types.MergePatchType, patchRunSpecStatus(status), metav1.PatchOptions{FieldManager: clients.FieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to cancel Tekton PipelineRun: %v", err)
+	}
+
+	return retry.RetryContext(context.Background(), timeout, func() *retry.RetryError {
+		pipelineRun, err := pipelineRuns.Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return retry.NonRetryableError(fmt.Errorf("failed to get Tekton PipelineRun: %v", err))
+		}
+		if !pipelineRun.IsDone() {
+			return retry.RetryableError(fmt.Errorf("Tekton PipelineRun %s is still running", name))
+		}
+		return nil
+	})
+}
+
+// cancelTaskRun cancels a TaskRun and waits until it is done.
+func cancelTaskRun(clients providerClients, namespace, name string, timeout time.Duration) error {
+	taskRuns := clients.TektonClient.TektonV1beta1().TaskRuns(namespace)
+
+	taskRun, err := taskRuns.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get Tekton TaskRun: %v", err)
+	}
+	if taskRun.IsDone() {
+		return nil
+	}
+
+	_, err = taskRuns.Patch(context.Background(), name, types.MergePatchType, patchRunSpecStatus(tektonv1beta1.TaskRunSpecStatusCancelled), metav1.PatchOptions{FieldManager: clients.FieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to cancel Tekton TaskRun: %v", err)
+	}
+
+	return retry.RetryContext(context.Background(), timeout, func() *retry.RetryError {
+		taskRun, err := taskRuns.Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return retry.NonRetryableError(fmt.Errorf("failed to get Tekton TaskRun: %v", err))
+		}
+		if !taskRun.IsDone() {
+			return retry.RetryableError(fmt.Errorf("Tekton TaskRun %s is still running", name))
+		}
+		return nil
+	})
+}
+
+// cancelCustomRun cancels a CustomRun and waits until its controller reports it done.
+func cancelCustomRun(clients providerClients, namespace, name string, timeout time.Duration) error {
+	customRuns := clients.TektonClient.TektonV1beta1().CustomRuns(namespace)
+
+	customRun, err := customRuns.Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get Tekton CustomRun: %v", err)
+	}
+	if customRun.IsDone() {
+		return nil
+	}
+
+	_, err = customRuns.Patch(context.Background(), name, types.MergePatchType, patchRunSpecStatus(string(tektonv1beta1.CustomRunSpecStatusCancelled)), metav1.PatchOptions{FieldManager: clients.FieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to cancel Tekton CustomRun: %v", err)
+	}
+
+	return retry.RetryContext(context.Background(), timeout, func() *retry.RetryError {
+		customRun, err := customRuns.Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return nil
+			}
+			return retry.NonRetryableError(fmt.Errorf("failed to get Tekton CustomRun: %v", err))
+		}
+		if !customRun.IsDone() {
+			return retry.RetryableError(fmt.Errorf("Tekton CustomRun %s is still running", name))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,128 @@
+package tekton
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonfake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+)
+
+func TestDestroyTektonPipelineRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		onDestroy     string
+		done          bool
+		missing       bool
+		wantExists    bool
+		wantPatched   bool
+		wantSpecState tektonv1beta1.PipelineRunSpecStatus
+	}{
+		{name: "delete", onDestroy: onDestroyDelete, wantExists: false},
+		{name: "delete missing", onDestroy: onDestroyDelete, missing: true, wantExists: false},
+		{name: "keep", onDestroy: onDestroyKeep, wantExists: true},
+		{name: "cancel", onDestroy: onDestroyCancel, wantExists: true, wantPatched: true, wantSpecState: tektonv1beta1.PipelineRunSpecStatusStoppedRunFinally},
+		{name: "cancel done", onDestroy: onDestroyCancel, done: true, wantExists: true},
+		{name: "cancel missing", onDestroy: onDestroyCancel, missing: true, wantExists: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objects []runtime.Object
+			if !tt.missing {
+				pipelineRun := &tektonv1beta1.PipelineRun{ObjectMeta: metav1.ObjectMeta{Name: "build-abc", Namespace: "ci"}}
+				if tt.done {
+					pipelineRun.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+				}
+				objects = append(objects, pipelineRun)
+			}
+			client := tektonfake.NewSimpleClientset(objects...)
+			// Stand in for the controller, which marks a cancelled run as done.
+			client.PrependReactor("get", "pipelineruns", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				get := action.(k8stesting.GetAction)
+				object, err := client.Tracker().Get(action.GetResource(), get.GetNamespace(), get.GetName())
+				if err != nil {
+					return true, nil, err
+				}
+				pipelineRun := object.(*tektonv1beta1.PipelineRun).DeepCopy()
+				if pipelineRun.Spec.Status != "" {
+					pipelineRun.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionFalse, Reason: "Cancelled"})
+				}
+				return true, pipelineRun, nil
+			})
+
+			d := schema.TestResourceDataRaw(t, resourceTektonPipelineRun().Schema, map[string]interface{}{
+				"namespace":         "ci",
+				"pipeline_ref_name": "build",
+				"on_destroy":        tt.onDestroy,
+				"cancel_status":     string(tektonv1beta1.PipelineRunSpecStatusStoppedRunFinally),
+				"cancel_timeout":    "10s",
+			})
+			clients := providerClients{TektonClient: client, FieldManager: "terraform"}
+
+			if err := destroyTektonPipelineRun(clients, d, "build-abc"); err != nil {
+				t.Fatalf("destroyTektonPipelineRun() error = %v", err)
+			}
+
+			object, err := client.Tracker().Get(k8sschema.GroupVersionResource{Group: "tekton.dev", Version: "v1beta1", Resource: "pipelineruns"}, "ci", "build-abc")
+			if exists := err == nil; exists != tt.wantExists {
+				t.Fatalf("run exists = %v, want %v", exists, tt.wantExists)
+			}
+			if err != nil && !errors.IsNotFound(err) {
+				t.Fatalf("failed to get run: %v", err)
+			}
+
+			patched := false
+			for _, action := range client.Actions() {
+				patched = patched || action.GetVerb() == "patch"
+			}
+			if patched != tt.wantPatched {
+				t.Errorf("patched = %v, want %v", patched, tt.wantPatched)
+			}
+			if tt.wantPatched {
+				if got := object.(*tektonv1beta1.PipelineRun).Spec.Status; got != tt.wantSpecState {
+					t.Errorf("spec.status = %q, want %q", got, tt.wantSpecState)
+				}
+			}
+		})
+	}
+}
+
+func TestDestroyTektonTaskRun(t *testing.T) {
+	tests := []struct {
+		onDestroy  string
+		wantExists bool
+	}{
+		{onDestroy: onDestroyDelete, wantExists: false},
+		{onDestroy: onDestroyKeep, wantExists: true},
+		{onDestroy: onDestroyCancel, wantExists: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.onDestroy, func(t *testing.T) {
+			taskRun := &tektonv1beta1.TaskRun{ObjectMeta: metav1.ObjectMeta{Name: "test-abc", Namespace: "ci"}}
+			taskRun.Status.SetCondition(&apis.Condition{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue})
+			client := tektonfake.NewSimpleClientset(taskRun)
+
+			d := schema.TestResourceDataRaw(t, resourceTektonTaskRun().Schema, map[string]interface{}{
+				"namespace":     "ci",
+				"task_ref_name": "test",
+				"on_destroy":    tt.onDestroy,
+			})
+			if err := destroyTektonTaskRun(providerClients{TektonClient: client}, d, "test-abc"); err != nil {
+				t.Fatalf("destroyTektonTaskRun() error = %v", err)
+			}
+
+			_, err := client.TektonV1beta1().TaskRuns("ci").Get(context.Background(), "test-abc", metav1.GetOptions{})
+			if exists := err == nil; exists != tt.wantExists {
+				t.Errorf("run exists = %v, want %v (%v)", exists, tt.wantExists, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,222 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// captureLogsSchema defines the capture_logs block shared by PipelineRuns and TaskRuns.
+func captureLogsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Wait for the run to finish and capture its step logs. A failed run fails the apply with the tail of the failing step's log.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"tail_lines": {
+					Type:        schema.TypeInt,
+					Optional:    true,
+					Default:     50,
+					Description: "Number of lines of the failing step's log to include in the error.",
+				},
+				"output_dir": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Local directory the full step logs are written to, as <output_dir>/<taskrun>/<step>.log.",
+				},
+				"timeout": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "1h",
+					ValidateFunc: validateDuration,
+					Description:  "How long to wait for the run to finish.",
+				},
+			},
+		},
+	}
+}
+
+// captureLogsConfig is the parsed form of a capture_logs block.
+type captureLogsConfig struct {
+	TailLines int
+	OutputDir string
+	Timeout   time.Duration
+}
+
+// getCaptureLogsConfig returns the capture_logs configuration, or nil when logs are not captured.
+func getCaptureLogsConfig(tfCaptureLogs []interface{}) *captureLogsConfig {
+	if len(tfCaptureLogs) == 0 || tfCaptureLogs[0] == nil {
+		return nil
+	}
+	configData := tfCaptureLogs[0].(map[string]interface{})
+	timeout, _ := time.ParseDuration(configData["timeout"].(string))
+
+	return &captureLogsConfig{
+		TailLines: configData["tail_lines"].(int),
+		OutputDir: configData["output_dir"].(string),
+		Timeout:   timeout,
+	}
+}
+
+// waitForPipelineRunLogs waits for a PipelineRun to finish, captures the logs of its
+// TaskRuns and returns an error with the failing step's log if the run did not succeed.
+func waitForPipelineRunLogs(clients providerClients, namespace, name string, config *captureLogsConfig) error {
+	var pipelineRun *tektonv1beta1.PipelineRun
+	err := retry.RetryContext(context.Background(), config.Timeout, func() *retry.RetryError {
+		var err error
+		pipelineRun, err = clients.TektonClient.TektonV1beta1().PipelineRuns(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return retry.NonRetryableError(fmt.Errorf("failed to get Tekton PipelineRun: %v", err))
+		}
+		if !pipelineRun.IsDone() {
+			return retry.RetryableError(fmt.Errorf("Tekton PipelineRun %s is still running", name))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	taskRuns, err := clients.TektonClient.TektonV1beta1().TaskRuns(namespace).List(context.Background(), metav1.ListOptions{
+		LabelSelector: "tekton.dev/pipelineRun=" + name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list TaskRuns of Tekton PipelineRun %s: %v", name, err)
+	}
+
+	var failure string
+	for i := range taskRuns.Items {
+		taskRunFailure, err := captureTaskRunLogs(clients, &taskRuns.Items[i], config)
+		if err != nil {
+			return err
+		}
+		if failure == "" {
+			failure = taskRunFailure
+		}
+	}
+
+	condition := pipelineRun.Status.GetCondition(apis.ConditionSucceeded)
+	if condition.IsTrue() {
+		return nil
+	}
+
+	message := fmt.Sprintf("Tekton PipelineRun %s did not succeed", name)
+	if condition != nil {
+		message += fmt.Sprintf(": %s: %s", condition.Reason, condition.Message)
+	}
+	if failure != "" {
+		message += "\n\n" + failure
+	}
+	return fmt.Errorf("%s", message)
+}
+
+// waitForTaskRunLogs waits for a TaskRun to finish, captures its logs and returns an
+// error with the failing step's log if the run did not succeed.
+func waitForTaskRunLogs(clients providerClients, namespace, name string, config *captureLogsConfig) error {
+	var taskRun *tektonv1beta1.TaskRun
+	err := retry.RetryContext(context.Background(), config.Timeout, func() *retry.RetryError {
+		var err error
+		taskRun, err = clients.TektonClient.TektonV1beta1().TaskRuns(namespace).Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return retry.NonRetryableError(fmt.Errorf("failed to get Tekton TaskRun: %v", err))
+		}
+		if !taskRun.IsDone() {
+			return retry.RetryableError(fmt.Errorf("Tekton TaskRun %s is still running", name))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	failure, err := captureTaskRunLogs(clients, taskRun, config)
+	if err != nil {
+		return err
+	}
+
+	if taskRun.IsSuccessful() {
+		return nil
+	}
+
+	message := fmt.Sprintf("Tekton TaskRun %s did not succeed", name)
+	if condition := taskRun.Status.GetCondition(apis.ConditionSucceeded); condition != nil {
+		message += fmt.Sprintf(": %s: %s", condition.Reason, condition.Message)
+	}
+	if failure != "" {
+		message += "\n\n" + failure
+	}
+	return fmt.Errorf("%s", message)
+}
+
+// captureTaskRunLogs reads the log of every step of a TaskRun's pod, writes them to the
+// output directory if configured and returns the tail of the first failing step's log.
+func captureTaskRunLogs(clients providerClients, taskRun *tektonv1beta1.TaskRun, config *captureLogsConfig) (string, error) {
+	if taskRun.Status.PodName == "" {
+		return "", nil
+	}
+
+	var failure string
+	for _, step := range taskRun.Status.Steps {
+		log, err := getContainerLog(clients, taskRun.Namespace, taskRun.Status.PodName, step.ContainerName)
+		if err != nil {
+			// The pod may already have been deleted; logs are best effort.
+			continue
+		}
+
+		if config.OutputDir != "" {
+			dir := filepath.Join(config.OutputDir, taskRun.Name)
+			if err := os.MkdirAll(dir, 0o755); err != nil {
+				return "", fmt.Errorf("failed to create log directory %s: %v", dir, err)
+			}
+			path := filepath.Join(dir, step.Name+".log")
+			if err := os.WriteFile(path, []byte(log), 0o644); err != nil {
+				return "", fmt.Errorf("failed to write log file %s: %v", path, err)
+			}
+		}
+
+		if failure == "" && step.Terminated != nil && step.Terminated.ExitCode != 0 {
+			failure = fmt.Sprintf("step %q of TaskRun %s exited with code %d:\n%s",
+				step.Name, taskRun.Name, step.Terminated.ExitCode, tailLines(log, config.TailLines))
+		}
+	}
+
+	return failure, nil
+}
+
+func getContainerLog(clients providerClients, namespace, podName, container string) (string, error) {
+	stream, err := clients.KubeClient.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: container,
+	}).Stream(context.Background())
+	if err != nil {
+		return "", err
+	}
+	defer stream.Close()
+
+	log, err := io.ReadAll(stream)
+	if err != nil {
+		return "", err
+	}
+	return string(log), nil
+}
+
+// tailLines returns the last n lines of s.
+func tailLines(s string, n int) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	if n > 0 && len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,347 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// runTriggerSchema defines the naming and re-run fields shared by PipelineRuns and TaskRuns.
+func runTriggerSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ExactlyOneOf: []string{"name", "generate_name"},
+		},
+		"generate_name": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			ExactlyOneOf: []string{"name", "generate_name"},
+			Description:  "Prefix used to generate a unique run name. A new run is created each time triggers change.",
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Arbitrary values that cause the run to be re-run when changed.",
+		},
+		"keep_previous_runs": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "In generate_name mode, keep the previous run in the cluster when triggers start a new one. Otherwise the previous run is cleaned up as on_destroy says.",
+		},
+	}
+}
+
+// customizeRunDiff re-runs a run when its triggers change: runs with a fixed name
+// are replaced, runs in generate_name mode get a freshly generated name on update.
+func customizeRunDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	if d.HasChange("triggers") {
+		if d.Get("generate_name").(string) == "" {
+			return d.ForceNew("triggers")
+		}
+		return d.SetNewComputed("name")
+	}
+
+	if d.HasChange("name") {
+		return d.ForceNew("name")
+	}
+
+	return nil
+}
+
+// getRunObjectMeta returns the metadata of a new run, using generate_name when set.
+func getRunObjectMeta(config metadataConfig, d *schema.ResourceData) metav1.ObjectMeta {
+	meta := getObjectMeta(config, d, "", d.Get("namespace").(string))
+	if v := d.Get("generate_name").(string); v != "" {
+		meta.GenerateName = v
+	} else {
+		meta.Name = d.Get("name").(string)
+	}
+	return meta
+}
+
+// durationSchema defines an optional Go duration string such as "1h30m".
+func durationSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Description:  description,
+		ValidateFunc: validateDuration,
+	}
+}
+
+func validateDuration(v interface{}, k string) ([]string, []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a duration such as \"1h30m\": %v", k, err)}
+	}
+	return nil, nil
+}
+
+// pipelineTimeoutsSchema defines the pipeline_timeouts block of a PipelineRun. It cannot be named
+// timeouts, which the SDK reserves for its own operation timeouts.
+func pipelineTimeoutsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pipeline": durationSchema("Timeout for the whole PipelineRun."),
+				"tasks":    durationSchema("Timeout for the PipelineRun's tasks, excluding finally tasks."),
+				"finally":  durationSchema("Timeout for the PipelineRun's finally tasks."),
+			},
+		},
+	}
+}
+
+// computeResourcesSchema defines compute resource limits and requests, e.g. { cpu = "500m", memory = "1Gi" }.
+func computeResourcesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"limits": {
+					Type:         schema.TypeMap,
+					Optional:     true,
+					Elem:         &schema.Schema{Type: schema.TypeString},
+					ValidateFunc: validateQuantities,
+				},
+				"requests": {
+					Type:         schema.TypeMap,
+					Optional:     true,
+					Elem:         &schema.Schema{Type: schema.TypeString},
+					ValidateFunc: validateQuantities,
+				},
+			},
+		},
+	}
+}
+
+// containerOverridesSchema defines step or sidecar resource overrides by container name.
+func containerOverridesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"resources": {
+					Type:     schema.TypeList,
+					Required: true,
+					MaxItems: 1,
+					Elem:     computeResourcesSchema().Elem,
+				},
+			},
+		},
+	}
+}
+
+// taskRunSpecsSchema defines the per pipeline task overrides of a PipelineRun.
+func taskRunSpecsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"pipeline_task_name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"service_account_name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"pod_template":      podTemplateSchema(),
+				"compute_resources": computeResourcesSchema(),
+				"step_overrides":    containerOverridesSchema(),
+				"sidecar_overrides": containerOverridesSchema(),
+			},
+		},
+	}
+}
+
+func parseDuration(s string) (*metav1.Duration, error) {
+	if s == "" {
+		return nil, nil
+	}
+	duration, err := time.ParseDuration(s)
+	if err != nil {
+		return nil, err
+	}
+	return &metav1.Duration{Duration: duration}, nil
+}
+
+// Helper function to convert a Terraform pipeline_timeouts block into Tekton timeout fields
+func getPipelineRunTimeouts(tfTimeouts []interface{}) (*tektonv1beta1.TimeoutFields, error) {
+	if len(tfTimeouts) == 0 || tfTimeouts[0] == nil {
+		return nil, nil
+	}
+	timeoutsData := tfTimeouts[0].(map[string]interface{})
+	timeouts := &tektonv1beta1.TimeoutFields{}
+
+	var err error
+	if timeouts.Pipeline, err = parseDuration(timeoutsData["pipeline"].(string)); err != nil {
+		return nil, fmt.Errorf("invalid pipeline timeout: %v", err)
+	}
+	if timeouts.Tasks, err = parseDuration(timeoutsData["tasks"].(string)); err != nil {
+		return nil, fmt.Errorf("invalid tasks timeout: %v", err)
+	}
+	if timeouts.Finally, err = parseDuration(timeoutsData["finally"].(string)); err != nil {
+		return nil, fmt.Errorf("invalid finally timeout: %v", err)
+	}
+
+	return timeouts, nil
+}
+
+// validateQuantities checks that every value of a map is a Kubernetes quantity such as "500m" or "1Gi".
+func validateQuantities(v interface{}, k string) ([]string, []error) {
+	var errs []error
+	for name, value := range v.(map[string]interface{}) {
+		if _, err := resource.ParseQuantity(value.(string)); err != nil {
+			errs = append(errs, fmt.Errorf("%s.%s must be a quantity such as \"500m\" or \"1Gi\": %v", k, name, err))
+		}
+	}
+	return nil, errs
+}
+
+func getResourceList(tfResources map[string]interface{}) (corev1.ResourceList, error) {
+	if len(tfResources) == 0 {
+		return nil, nil
+	}
+	resources := corev1.ResourceList{}
+	for name, value := range tfResources {
+		quantity, err := resource.ParseQuantity(value.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid quantity %q for %s: %v", value, name, err)
+		}
+		resources[corev1.ResourceName(name)] = quantity
+	}
+	return resources, nil
+}
+
+// Helper function to convert a Terraform compute_resources block into resource requirements
+func getComputeResources(tfResources []interface{}) (*corev1.ResourceRequirements, error) {
+	if len(tfResources) == 0 || tfResources[0] == nil {
+		return nil, nil
+	}
+	resourcesData := tfResources[0].(map[string]interface{})
+
+	limits, err := getResourceList(resourcesData["limits"].(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+	requests, err := getResourceList(resourcesData["requests"].(map[string]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &corev1.ResourceRequirements{Limits: limits, Requests: requests}, nil
+}
+
+// flattenComputeResources converts resource requirements into a compute_resources block.
+func flattenComputeResources(resources corev1.ResourceRequirements) []interface{} {
+	if len(resources.Limits) == 0 && len(resources.Requests) == 0 {
+		return nil
+	}
+	return []interface{}{map[string]interface{}{
+		"limits":   flattenResourceList(resources.Limits),
+		"requests": flattenResourceList(resources.Requests),
+	}}
+}
+
+func flattenResourceList(resources corev1.ResourceList) map[string]interface{} {
+	tfResources := map[string]interface{}{}
+	for name, quantity := range resources {
+		tfResources[string(name)] = quantity.String()
+	}
+	return tfResources
+}
+
+func getStepOverrides(tfOverrides []interface{}) ([]tektonv1beta1.TaskRunStepOverride, error) {
+	var overrides []tektonv1beta1.TaskRunStepOverride
+	for _, tfOverride := range tfOverrides {
+		overrideData := tfOverride.(map[string]interface{})
+		resources, err := getComputeResources(overrideData["resources"].([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		override := tektonv1beta1.TaskRunStepOverride{Name: overrideData["name"].(string)}
+		if resources != nil {
+			override.Resources = *resources
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+func getSidecarOverrides(tfOverrides []interface{}) ([]tektonv1beta1.TaskRunSidecarOverride, error) {
+	var overrides []tektonv1beta1.TaskRunSidecarOverride
+	for _, tfOverride := range tfOverrides {
+		overrideData := tfOverride.(map[string]interface{})
+		resources, err := getComputeResources(overrideData["resources"].([]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		override := tektonv1beta1.TaskRunSidecarOverride{Name: overrideData["name"].(string)}
+		if resources != nil {
+			override.Resources = *resources
+		}
+		overrides = append(overrides, override)
+	}
+	return overrides, nil
+}
+
+// Helper function to convert Terraform task_run_specs into Tekton pipeline task run specs
+func getPipelineTaskRunSpecs(tfSpecs []interface{}, rawSpecs cty.Value) ([]tektonv1beta1.PipelineTaskRunSpec, error) {
+	var specs []tektonv1beta1.PipelineTaskRunSpec
+
+	for i, tfSpec := range tfSpecs {
+		specData := tfSpec.(map[string]interface{})
+		pipelineTaskName := specData["pipeline_task_name"].(string)
+
+		computeResources, err := getComputeResources(specData["compute_resources"].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("task_run_specs %q: %v", pipelineTaskName, err)
+		}
+		stepOverrides, err := getStepOverrides(specData["step_overrides"].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("task_run_specs %q: %v", pipelineTaskName, err)
+		}
+		sidecarOverrides, err := getSidecarOverrides(specData["sidecar_overrides"].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("task_run_specs %q: %v", pipelineTaskName, err)
+		}
+
+		specs = append(specs, tektonv1beta1.PipelineTaskRunSpec{
+			PipelineTaskName:       pipelineTaskName,
+			TaskServiceAccountName: specData["service_account_name"].(string),
+			TaskPodTemplate:        getPodTemplate(specData["pod_template"].([]interface{}), rawConfigAt(rawSpecs, i, "pod_template")),
+			ComputeResources:       computeResources,
+			StepOverrides:          stepOverrides,
+			SidecarOverrides:       sidecarOverrides,
+		})
+	}
+
+	return specs, nil
+}
@@ -0,0 +1,96 @@
+package tekton
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestValidateQuantities(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   map[string]interface{}
+		wantErr string
+	}{
+		{name: "empty", value: map[string]interface{}{}},
+		{name: "valid", value: map[string]interface{}{"cpu": "500m", "memory": "1Gi", "nvidia.com/gpu": "1"}},
+		{name: "invalid", value: map[string]interface{}{"cpu": "500m", "memory": "1 GB"}, wantErr: `limits.memory must be a quantity such as "500m" or "1Gi"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateQuantities(tt.value, "limits")
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("validateQuantities() = %v, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("validateQuantities() = %v, want an error containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestGetComputeResources(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []interface{}
+		want      *corev1.ResourceRequirements
+		wantErr   string
+	}{
+		{name: "unset", resources: nil, want: nil},
+		{
+			name: "limits and requests",
+			resources: []interface{}{map[string]interface{}{
+				"limits":   map[string]interface{}{"memory": "1Gi"},
+				"requests": map[string]interface{}{"cpu": "500m", "memory": "512Mi"},
+			}},
+			want: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("512Mi"),
+				},
+			},
+		},
+		{
+			name: "requests only",
+			resources: []interface{}{map[string]interface{}{
+				"limits":   map[string]interface{}{},
+				"requests": map[string]interface{}{"cpu": "1"},
+			}},
+			want: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			},
+		},
+		{
+			name: "invalid quantity",
+			resources: []interface{}{map[string]interface{}{
+				"limits":   map[string]interface{}{"cpu": "half"},
+				"requests": map[string]interface{}{},
+			}},
+			wantErr: `invalid quantity "half" for cpu`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getComputeResources(tt.resources)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("getComputeResources() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getComputeResources() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("getComputeResources() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,428 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tektonv1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// resourceTektonStepAction defines a Tekton StepAction, a step shared by many Tasks.
+func resourceTektonStepAction() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTektonStepActionCreate,
+		Read:   resourceTektonStepActionRead,
+		Update: resourceTektonStepActionUpdate,
+		Delete: resourceTektonStepActionDelete,
+
+		CustomizeDiff: customizeLabelsDiff,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+				ForceNew: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"image": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"command": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				Elem:          &schema.Schema{Type: schema.TypeString},
+				ConflictsWith: []string{"script"},
+			},
+			"args": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"script": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"command"},
+			},
+			"working_dir": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"env": envSchema("Environment variables of the step."),
+			"params": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      string(tektonv1.ParamTypeString),
+							ValidateFunc: validation.StringInSlice([]string{string(tektonv1.ParamTypeString), string(tektonv1.ParamTypeArray), string(tektonv1.ParamTypeObject)}, false),
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"default": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The default value of a string param. Empty means the param has no default.",
+						},
+					},
+				},
+			},
+			"results": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      string(tektonv1.ResultsTypeString),
+							ValidateFunc: validation.StringInSlice([]string{string(tektonv1.ResultsTypeString), string(tektonv1.ResultsTypeArray), string(tektonv1.ResultsTypeObject)}, false),
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"volume_mounts": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"mount_path": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"sub_path": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"read_only": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"security_context": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"run_as_user": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"run_as_group": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"run_as_non_root": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"privileged": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"read_only_root_filesystem": {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						"allow_privilege_escalation": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+					},
+				},
+			},
+		}, metadataSchema()),
+	}
+}
+
+// resourceTektonStepActionCreate creates a Tekton StepAction.
+func resourceTektonStepActionCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+	namespace := d.Get("namespace").(string)
+
+	stepAction := &tektonv1beta1.StepAction{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       getStepActionSpec(d),
+	}
+
+	if _, err := clients.TektonClient.TektonV1beta1().StepActions(namespace).Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "StepAction", namespace, name)
+	}
+
+	patch, err := getApplyPatch(stepAction)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1beta1().StepActions(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create Tekton StepAction: %v", err)
+	}
+
+	d.SetId(name)
+	return resourceTektonStepActionRead(d, m)
+}
+
+func resourceTektonStepActionRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	stepAction, err := clients.TektonClient.TektonV1beta1().StepActions(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	setObjectMeta(clients, d, stepAction.ObjectMeta)
+
+	meta, spec := stepAction.ObjectMeta, stepAction.Spec
+	setManagedField(clients, d, meta, "description", spec.Description, "spec", "description")
+	setManagedField(clients, d, meta, "image", spec.Image, "spec", "image")
+	setManagedField(clients, d, meta, "command", spec.Command, "spec", "command")
+	setManagedField(clients, d, meta, "args", spec.Args, "spec", "args")
+	setManagedField(clients, d, meta, "script", spec.Script, "spec", "script")
+	setManagedField(clients, d, meta, "working_dir", spec.WorkingDir, "spec", "workingDir")
+	setManagedField(clients, d, meta, "env", flattenEnvVars(spec.Env), "spec", "env")
+	setManagedField(clients, d, meta, "params", flattenStepActionParams(spec.Params), "spec", "params")
+	setManagedField(clients, d, meta, "results", flattenStepActionResults(spec.Results), "spec", "results")
+	setManagedField(clients, d, meta, "volume_mounts", flattenVolumeMounts(spec.VolumeMounts), "spec", "volumeMounts")
+	setManagedField(clients, d, meta, "security_context", flattenSecurityContext(spec.SecurityContext), "spec", "securityContext")
+
+	return nil
+}
+
+func resourceTektonStepActionUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	stepAction := &tektonv1beta1.StepAction{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       getStepActionSpec(d),
+	}
+
+	patch, err := getApplyPatch(stepAction)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1beta1().StepActions(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton StepAction: %v", err)
+	}
+
+	return resourceTektonStepActionRead(d, m)
+}
+
+func resourceTektonStepActionDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	err := clients.TektonClient.TektonV1beta1().StepActions(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete Tekton StepAction: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Helper function to convert the Terraform step action configuration into a Tekton StepAction spec
+func getStepActionSpec(d *schema.ResourceData) tektonv1beta1.StepActionSpec {
+	return tektonv1beta1.StepActionSpec{
+		Description:     d.Get("description").(string),
+		Image:           d.Get("image").(string),
+		Command:         toStringSlice(d.Get("command").([]interface{})),
+		Args:            toStringSlice(d.Get("args").([]interface{})),
+		Script:          d.Get("script").(string),
+		WorkingDir:      d.Get("working_dir").(string),
+		Env:             getEnvVars(d.Get("env").([]interface{})),
+		Params:          getStepActionParams(d.Get("params").([]interface{})),
+		Results:         getStepActionResults(d.Get("results").([]interface{})),
+		VolumeMounts:    getVolumeMounts(d.Get("volume_mounts").([]interface{})),
+		SecurityContext: getSecurityContext(d.Get("security_context").([]interface{}), d.GetRawConfig().GetAttr("security_context")),
+	}
+}
+
+// Helper function to convert Terraform params into Tekton param specs
+func getStepActionParams(tfParams []interface{}) tektonv1.ParamSpecs {
+	var params tektonv1.ParamSpecs
+	for _, tfParam := range tfParams {
+		paramData := tfParam.(map[string]interface{})
+		param := tektonv1.ParamSpec{
+			Name:        paramData["name"].(string),
+			Type:        tektonv1.ParamType(paramData["type"].(string)),
+			Description: paramData["description"].(string),
+		}
+		if v := paramData["default"].(string); v != "" {
+			param.Default = tektonv1.NewStructuredValues(v)
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// Helper function to convert Terraform results into Tekton step results
+func getStepActionResults(tfResults []interface{}) []tektonv1.StepResult {
+	var results []tektonv1.StepResult
+	for _, tfResult := range tfResults {
+		resultData := tfResult.(map[string]interface{})
+		results = append(results, tektonv1.StepResult{
+			Name:        resultData["name"].(string),
+			Type:        tektonv1.ResultsType(resultData["type"].(string)),
+			Description: resultData["description"].(string),
+		})
+	}
+	return results
+}
+
+// Helper function to convert Terraform volume mounts into container volume mounts
+func getVolumeMounts(tfVolumeMounts []interface{}) []corev1.VolumeMount {
+	var volumeMounts []corev1.VolumeMount
+	for _, tfVolumeMount := range tfVolumeMounts {
+		mountData := tfVolumeMount.(map[string]interface{})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      mountData["name"].(string),
+			MountPath: mountData["mount_path"].(string),
+			SubPath:   mountData["sub_path"].(string),
+			ReadOnly:  mountData["read_only"].(bool),
+		})
+	}
+	return volumeMounts
+}
+
+// Helper function to convert a Terraform security context into a container security context. The
+// raw config of the block tells run_as_user = 0 apart from an unset run_as_user.
+func getSecurityContext(tfSecurityContext []interface{}, rawSecurityContext cty.Value) *corev1.SecurityContext {
+	if len(tfSecurityContext) == 0 || tfSecurityContext[0] == nil {
+		return nil
+	}
+	contextData := tfSecurityContext[0].(map[string]interface{})
+
+	runAsNonRoot := contextData["run_as_non_root"].(bool)
+	privileged := contextData["privileged"].(bool)
+	readOnlyRootFilesystem := contextData["read_only_root_filesystem"].(bool)
+	allowPrivilegeEscalation := contextData["allow_privilege_escalation"].(bool)
+	securityContext := &corev1.SecurityContext{
+		RunAsNonRoot:             &runAsNonRoot,
+		Privileged:               &privileged,
+		ReadOnlyRootFilesystem:   &readOnlyRootFilesystem,
+		AllowPrivilegeEscalation: &allowPrivilegeEscalation,
+	}
+	if v := int64(contextData["run_as_user"].(int)); v > 0 || rawConfigSet(rawSecurityContext, 0, "run_as_user") {
+		securityContext.RunAsUser = &v
+	}
+	if v := int64(contextData["run_as_group"].(int)); v > 0 || rawConfigSet(rawSecurityContext, 0, "run_as_group") {
+		securityContext.RunAsGroup = &v
+	}
+	return securityContext
+}
+
+func flattenStepActionParams(params tektonv1.ParamSpecs) []interface{} {
+	var tfParams []interface{}
+	for _, param := range params {
+		tfParam := map[string]interface{}{
+			"name":        param.Name,
+			"type":        string(param.Type),
+			"description": param.Description,
+		}
+		if param.Default != nil {
+			tfParam["default"] = param.Default.StringVal
+		}
+		tfParams = append(tfParams, tfParam)
+	}
+	return tfParams
+}
+
+func flattenStepActionResults(results []tektonv1.StepResult) []interface{} {
+	var tfResults []interface{}
+	for _, result := range results {
+		tfResults = append(tfResults, map[string]interface{}{
+			"name":        result.Name,
+			"type":        string(result.Type),
+			"description": result.Description,
+		})
+	}
+	return tfResults
+}
+
+func flattenVolumeMounts(volumeMounts []corev1.VolumeMount) []interface{} {
+	var tfVolumeMounts []interface{}
+	for _, mount := range volumeMounts {
+		tfVolumeMounts = append(tfVolumeMounts, map[string]interface{}{
+			"name":       mount.Name,
+			"mount_path": mount.MountPath,
+			"sub_path":   mount.SubPath,
+			"read_only":  mount.ReadOnly,
+		})
+	}
+	return tfVolumeMounts
+}
+
+// flattenSecurityContext converts a container security context into a security_context block.
+// Unset booleans take the defaults of the block.
+func flattenSecurityContext(securityContext *corev1.SecurityContext) []interface{} {
+	if securityContext == nil {
+		return nil
+	}
+	boolValue := func(b *bool, defaultValue bool) bool {
+		if b == nil {
+			return defaultValue
+		}
+		return *b
+	}
+	tfContext := map[string]interface{}{
+		"run_as_non_root":            boolValue(securityContext.RunAsNonRoot, false),
+		"privileged":                 boolValue(securityContext.Privileged, false),
+		"read_only_root_filesystem":  boolValue(securityContext.ReadOnlyRootFilesystem, false),
+		"allow_privilege_escalation": boolValue(securityContext.AllowPrivilegeEscalation, true),
+	}
+	if securityContext.RunAsUser != nil {
+		tfContext["run_as_user"] = int(*securityContext.RunAsUser)
+	}
+	if securityContext.RunAsGroup != nil {
+		tfContext["run_as_group"] = int(*securityContext.RunAsGroup)
+	}
+	return []interface{}{tfContext}
+}
@@ -0,0 +1,47 @@
+package tekton
+
+import (
+	"testing"
+
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonfake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceTektonStepActionRead(t *testing.T) {
+	d := testResourceData(t, resourceTektonStepAction(), map[string]interface{}{
+		"name":        "git-clone",
+		"namespace":   "ci",
+		"description": "Clones a git repository.",
+		"image":       "alpine/git",
+		"command":     []interface{}{"git"},
+		"args":        []interface{}{"clone", "$(params.url)", "$(params.path)"},
+		"working_dir": "/workspace",
+		"env": []interface{}{
+			map[string]interface{}{"name": "GIT_TERMINAL_PROMPT", "value": "0"},
+			map[string]interface{}{"name": "GIT_TOKEN", "secret_key_ref": []interface{}{map[string]interface{}{"name": "git", "key": "token"}}},
+		},
+		"params": []interface{}{
+			map[string]interface{}{"name": "url", "description": "The repository to clone."},
+			map[string]interface{}{"name": "path", "default": "source"},
+		},
+		"results":       []interface{}{map[string]interface{}{"name": "commit"}},
+		"volume_mounts": []interface{}{map[string]interface{}{"name": "cache", "mount_path": "/cache", "read_only": true}},
+		"security_context": []interface{}{map[string]interface{}{
+			"run_as_user":     1000,
+			"run_as_non_root": true,
+		}},
+	})
+	spec := getStepActionSpec(d)
+	d.SetId("git-clone")
+
+	clients := providerClients{
+		TektonClient: tektonfake.NewSimpleClientset(&tektonv1beta1.StepAction{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-clone", Namespace: "ci"},
+			Spec:       spec,
+		}),
+		FieldManager: "terraform",
+	}
+	testReadKeepsConfig(t, d, resourceTektonStepActionRead, clients,
+		"description", "image", "command", "args", "script", "working_dir", "env", "params", "results", "volume_mounts", "security_context")
+}
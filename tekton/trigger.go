@@ -0,0 +1,192 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func resourceTektonTrigger() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTektonTriggerCreate,
+		Read:   resourceTektonTriggerRead,
+		Update: resourceTektonTriggerUpdate,
+		Delete: resourceTektonTriggerDelete,
+
+		CustomizeDiff: customdiff.Sequence(customizeTriggerDiff, customizeLabelsDiff),
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+				ForceNew: true,
+			},
+			"service_account_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The ServiceAccount used to create the resources of this trigger, in the Trigger's namespace.",
+			},
+			"bindings": triggerBindingsSchema(),
+			"template": func() *schema.Schema {
+				template := triggerTemplateSchema()
+				template.Optional = false
+				template.Required = true
+				return template
+			}(),
+			"interceptors": triggerInterceptorsSchema(),
+		}, metadataSchema()),
+	}
+}
+
+func resourceTektonTriggerCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+	namespace := d.Get("namespace").(string)
+
+	spec, err := getTriggerSpec(d)
+	if err != nil {
+		return err
+	}
+
+	trigger := &tektonv1alpha1.Trigger{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       *spec,
+	}
+
+	if _, err := clients.TektonTriggersClient.TriggersV1alpha1().Triggers(namespace).Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "Trigger", namespace, name)
+	}
+
+	patch, err := getApplyPatch(trigger)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().Triggers(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create Tekton Trigger: %v", err)
+	}
+
+	d.SetId(name)
+	return resourceTektonTriggerRead(d, m)
+}
+
+func resourceTektonTriggerRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	trigger, err := clients.TektonTriggersClient.TriggersV1alpha1().Triggers(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	setObjectMeta(clients, d, trigger.ObjectMeta)
+
+	if managesField(clients, trigger.ObjectMeta, "spec", "serviceAccountName") {
+		d.Set("service_account_name", trigger.Spec.ServiceAccountName)
+	} else {
+		d.Set("service_account_name", "")
+	}
+
+	interceptors := flattenTriggerInterceptors(trigger.Spec.Interceptors, d.Get("interceptors").([]interface{}))
+	setManagedField(clients, d, trigger.ObjectMeta, "bindings", flattenTriggerSpecBindings(trigger.Spec.Bindings), "spec", "bindings")
+	setManagedField(clients, d, trigger.ObjectMeta, "template", flattenTriggerSpecTemplate(&trigger.Spec.Template), "spec", "template")
+	setManagedField(clients, d, trigger.ObjectMeta, "interceptors", interceptors, "spec", "interceptors")
+
+	return nil
+}
+
+func resourceTektonTriggerUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	spec, err := getTriggerSpec(d)
+	if err != nil {
+		return err
+	}
+
+	trigger := &tektonv1alpha1.Trigger{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       *spec,
+	}
+
+	patch, err := getApplyPatch(trigger)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().Triggers(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton Trigger: %v", err)
+	}
+
+	return resourceTektonTriggerRead(d, m)
+}
+
+func resourceTektonTriggerDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	err := clients.TektonTriggersClient.TriggersV1alpha1().Triggers(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete Tekton Trigger: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// customizeTriggerDiff checks at plan time that the bindings supply the params of the template that have no default.
+func customizeTriggerDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	clients, ok := m.(providerClients)
+	if !ok || !rawPlanKnown(d, "namespace", "bindings", "template") {
+		return nil
+	}
+
+	bindings, err := getTriggerSpecBindings(d.Get("bindings").([]interface{}))
+	if err != nil {
+		return err
+	}
+	template, err := getTriggerSpecTemplate(d.Get("template").([]interface{}))
+	if err != nil {
+		return err
+	}
+	return checkTriggerParamsBound(clients, d.Get("namespace").(string), bindings, template)
+}
+
+// Helper function to convert the Terraform trigger configuration into a Tekton Trigger spec
+func getTriggerSpec(d *schema.ResourceData) (*tektonv1alpha1.TriggerSpec, error) {
+	bindings, err := getTriggerSpecBindings(d.Get("bindings").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := getTriggerSpecTemplate(d.Get("template").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	interceptors, err := getTriggerInterceptors(d.Get("interceptors").([]interface{}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tektonv1alpha1.TriggerSpec{
+		Bindings:           bindings,
+		Template:           *template,
+		Interceptors:       interceptors,
+		ServiceAccountName: d.Get("service_account_name").(string),
+	}, nil
+}
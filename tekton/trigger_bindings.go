@@ -3,12 +3,14 @@ package tekton
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
 	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
-	triggersclient "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/jsonpath"
 )
 
 func resourceTektonTriggerBinding() *schema.Resource {
@@ -18,30 +20,41 @@ func resourceTektonTriggerBinding() *schema.Resource {
 		Update: resourceTektonTriggerBindingUpdate,
 		Delete: resourceTektonTriggerBindingDelete,
 
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: customizeLabelsDiff,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"namespace": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "default",
+				ForceNew: true,
 			},
-			"bindings": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-					},
+			"bindings": triggerBindingParamsSchema(),
+		}, metadataSchema()),
+	}
+}
+
+// triggerBindingParamsSchema defines the params of a TriggerBinding or ClusterTriggerBinding.
+func triggerBindingParamsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Required: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Required: true,
+				},
+				"value": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validateTriggerBindingValue,
+					Description:  "The value of the param, e.g. \"$(body.head_commit.id)\".",
 				},
 			},
 		},
@@ -49,26 +62,27 @@ func resourceTektonTriggerBinding() *schema.Resource {
 }
 
 func resourceTektonTriggerBindingCreate(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Get("name").(string)
 	namespace := d.Get("namespace").(string)
 
 	bindings := getTriggerBindingParams(d.Get("bindings").([]interface{}))
 
 	triggerBinding := &tektonv1alpha1.TriggerBinding{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
 		Spec: tektonv1alpha1.TriggerBindingSpec{
 			Params: bindings,
 		},
 	}
 
-	_, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerBindings(namespace).Create(context.Background(), triggerBinding, metav1.CreateOptions{})
+	if _, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerBindings(namespace).Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "TriggerBinding", namespace, name)
+	}
+
+	patch, err := getApplyPatch(triggerBinding)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().TriggerBindings(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create Tekton TriggerBinding: %v", err)
 	}
@@ -78,31 +92,54 @@ func resourceTektonTriggerBindingCreate(d *schema.ResourceData, m interface{}) e
 }
 
 func resourceTektonTriggerBindingRead(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
-	_, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerBindings(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	triggerBinding, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerBindings(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
 		d.SetId("")
 		return nil
 	}
 
+	setObjectMeta(clients, d, triggerBinding.ObjectMeta)
+
+	if managesField(clients, triggerBinding.ObjectMeta, "spec", "params") {
+		d.Set("bindings", flattenTriggerBindingParams(triggerBinding.Spec.Params))
+	} else {
+		d.Set("bindings", nil)
+	}
+
 	return nil
 }
 
 func resourceTektonTriggerBindingUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	bindings := getTriggerBindingParams(d.Get("bindings").([]interface{}))
+
+	triggerBinding := &tektonv1alpha1.TriggerBinding{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec: tektonv1alpha1.TriggerBindingSpec{
+			Params: bindings,
+		},
+	}
+
+	patch, err := getApplyPatch(triggerBinding)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().TriggerBindings(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton TriggerBinding: %v", err)
+	}
+
 	return resourceTektonTriggerBindingRead(d, m)
 }
 
 func resourceTektonTriggerBindingDelete(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
@@ -120,10 +157,72 @@ func getTriggerBindingParams(tfBindings []interface{}) []tektonv1alpha1.Param {
 	for _, tfBinding := range tfBindings {
 		bindingData := tfBinding.(map[string]interface{})
 		binding := tektonv1alpha1.Param{
-			Name: bindingData["name"].(string),
-			// Value: tektonv1alpha1.ArrayOrString{StringVal: bindingData["value"].(string)},
+			Name:  bindingData["name"].(string),
+			Value: bindingData["value"].(string),
 		}
 		bindings = append(bindings, binding)
 	}
 	return bindings
 }
+
+func flattenTriggerBindingParams(params []tektonv1alpha1.Param) []interface{} {
+	var tfBindings []interface{}
+	for _, param := range params {
+		tfBindings = append(tfBindings, map[string]interface{}{
+			"name":  param.Name,
+			"value": param.Value,
+		})
+	}
+	return tfBindings
+}
+
+// validateTriggerBindingValue checks that every $() expression in a binding value is
+// terminated and is a JSONPath expression over the event body, headers, extensions or context.
+func validateTriggerBindingValue(v interface{}, k string) ([]string, []error) {
+	value := v.(string)
+
+	exprs := findBindingExpressions(value)
+	if len(exprs) != strings.Count(value, "$(") {
+		return nil, []error{fmt.Errorf("%q has an unterminated $() expression: %s", k, value)}
+	}
+
+	var errs []error
+	for _, expr := range exprs {
+		// The root ends at the first field or bracket, as in body.repo or header['X-Hub-Signature'].
+		root := strings.TrimPrefix(expr, ".")
+		if i := strings.IndexAny(root, ".["); i >= 0 {
+			root = root[:i]
+		}
+		switch root {
+		case "body", "header", "extensions", "context":
+		default:
+			errs = append(errs, fmt.Errorf("%q: $(%s) must start with body, header, extensions or context", k, expr))
+			continue
+		}
+		if err := jsonpath.New(k).Parse("{." + strings.TrimPrefix(expr, ".") + "}"); err != nil {
+			errs = append(errs, fmt.Errorf("%q: $(%s) is not a valid JSONPath expression: %v", k, expr, err))
+		}
+	}
+	return nil, errs
+}
+
+// findBindingExpressions returns the contents of every balanced $() expression in s.
+func findBindingExpressions(s string) []string {
+	var exprs []string
+	parts := strings.Split(s, "$(")
+	for _, part := range parts[1:] {
+		depth := 0
+		for i, ch := range part {
+			if ch == '(' {
+				depth++
+			} else if ch == ')' {
+				if depth == 0 {
+					exprs = append(exprs, part[:i])
+					break
+				}
+				depth--
+			}
+		}
+	}
+	return exprs
+}
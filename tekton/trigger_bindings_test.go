@@ -0,0 +1,64 @@
+package tekton
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateTriggerBindingValue(t *testing.T) {
+	tests := []struct {
+		value   string
+		wantErr string
+	}{
+		{value: "main"},
+		{value: "$(body.repository.full_name)"},
+		{value: "$(body.head_commit.id)-$(header.X-Request-Id)"},
+		{value: "$(header.X-GitHub-Event)"},
+		{value: "$(body['x-key'])"},
+		{value: "$(header['X-Hub-Signature'])"},
+		{value: "$(body.commits[0].id)"},
+		{value: "$(extensions.short_sha)"},
+		{value: "$(context.eventID)"},
+		{value: "$(.body.ref)"},
+		{value: "$(body.ref", wantErr: "unterminated"},
+		{value: "$(bodyx.ref)", wantErr: "must start with body, header, extensions or context"},
+		{value: "$(params.revision)", wantErr: "must start with body, header, extensions or context"},
+		{value: "$(['body'].ref)", wantErr: "must start with body, header, extensions or context"},
+		{value: "$(body.ref[)", wantErr: "not a valid JSONPath expression"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			_, errs := validateTriggerBindingValue(tt.value, "value")
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("validateTriggerBindingValue(%q) = %v, want no errors", tt.value, errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("validateTriggerBindingValue(%q) = %v, want an error containing %q", tt.value, errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFindBindingExpressions(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []string
+	}{
+		{value: "main", want: nil},
+		{value: "$(body.ref)", want: []string{"body.ref"}},
+		{value: "$(body.a)/$(body.b)", want: []string{"body.a", "body.b"}},
+		{value: "$(body.items[?(@.name=='x')].id)", want: []string{"body.items[?(@.name=='x')].id"}},
+		{value: "$(body.ref", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := findBindingExpressions(tt.value); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findBindingExpressions(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,694 @@
+package tekton
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tektonv1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+)
+
+// gitInterceptors are the built-in ClusterInterceptors that validate webhooks with a shared secret.
+var gitInterceptors = []string{"github", "gitlab", "bitbucket"}
+
+// triggerBindingsSchema defines the bindings of a trigger, each either a reference to a
+// TriggerBinding or ClusterTriggerBinding, or an inline name/value param.
+func triggerBindingsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ref": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The name of the TriggerBinding or ClusterTriggerBinding to use.",
+				},
+				"kind": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Default:  string(tektonv1alpha1.NamespacedTriggerBindingKind),
+					ValidateFunc: validation.StringInSlice([]string{
+						string(tektonv1alpha1.NamespacedTriggerBindingKind),
+						string(tektonv1alpha1.ClusterTriggerBindingKind),
+					}, false),
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The name of an inline binding param. Mutually exclusive with ref.",
+				},
+				"value": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					ValidateFunc: validateTriggerBindingValue,
+					Description:  "The value of an inline binding param, e.g. \"$(body.head_commit.id)\".",
+				},
+			},
+		},
+	}
+}
+
+// triggerTemplateSchema defines the template of a trigger, either a reference to a
+// TriggerTemplate or an inline TriggerTemplate spec.
+func triggerTemplateSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"ref": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "The name of the TriggerTemplate to use.",
+				},
+				"spec": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "An inline TriggerTemplate spec. Mutually exclusive with ref.",
+					Elem: &schema.Resource{
+						Schema: triggerTemplateSpecSchema(),
+					},
+				},
+			},
+		},
+	}
+}
+
+// gitInterceptorSchema defines the github, gitlab and bitbucket interceptor blocks.
+func gitInterceptorSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"secret_ref": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					MaxItems:    1,
+					Description: "The secret holding the shared webhook secret.",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"secret_name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"secret_key": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+						},
+					},
+				},
+				"event_types": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+					Description: "Event types to accept, e.g. [\"push\"].",
+				},
+			},
+		},
+	}
+}
+
+// triggerInterceptorsSchema defines the interceptors of a trigger. Each interceptor sets
+// exactly one of the built-in github, gitlab, bitbucket or cel interceptors, a webhook,
+// or a ref to any other Interceptor or ClusterInterceptor.
+func triggerInterceptorsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": {
+					Type:     schema.TypeString,
+					Optional: true,
+				},
+				"github":    gitInterceptorSchema(),
+				"gitlab":    gitInterceptorSchema(),
+				"bitbucket": gitInterceptorSchema(),
+				"cel": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"filter": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "A CEL expression events must match, e.g. \"body.ref == 'refs/heads/main'\".",
+							},
+							"overlays": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"key": {
+											Type:     schema.TypeString,
+											Required: true,
+										},
+										"expression": {
+											Type:     schema.TypeString,
+											Required: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"webhook": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"url": {
+								Type:        schema.TypeString,
+								Optional:    true,
+								Description: "The URL of the webhook interceptor. Mutually exclusive with service.",
+							},
+							"service": {
+								Type:     schema.TypeList,
+								Optional: true,
+								MaxItems: 1,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"name": {
+											Type:     schema.TypeString,
+											Required: true,
+										},
+										"namespace": {
+											Type:     schema.TypeString,
+											Optional: true,
+										},
+									},
+								},
+							},
+							"headers": {
+								Type:     schema.TypeList,
+								Optional: true,
+								Elem: &schema.Resource{
+									Schema: map[string]*schema.Schema{
+										"name": {
+											Type:     schema.TypeString,
+											Required: true,
+										},
+										"values": {
+											Type:     schema.TypeList,
+											Required: true,
+											Elem:     &schema.Schema{Type: schema.TypeString},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				"ref": {
+					Type:     schema.TypeList,
+					Optional: true,
+					MaxItems: 1,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Type:     schema.TypeString,
+								Required: true,
+							},
+							"kind": {
+								Type:     schema.TypeString,
+								Optional: true,
+								Default:  string(tektonv1alpha1.ClusterInterceptorKind),
+								ValidateFunc: validation.StringInSlice([]string{
+									string(tektonv1alpha1.ClusterInterceptorKind),
+									string(tektonv1alpha1.NamespacedInterceptorKind),
+								}, false),
+							},
+							"params": {
+								Type:         schema.TypeMap,
+								Optional:     true,
+								Elem:         &schema.Schema{Type: schema.TypeString},
+								ValidateFunc: validateJSONValues,
+								Description:  "Params sent to the interceptor, each value as JSON (e.g. from jsonencode).",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// validateJSONValues checks that every value of a map is a JSON document.
+func validateJSONValues(v interface{}, k string) ([]string, []error) {
+	var errs []error
+	for key, value := range v.(map[string]interface{}) {
+		if _, err := structure.NormalizeJsonString(value); err != nil {
+			errs = append(errs, fmt.Errorf("%s.%s must be JSON: %v", k, key, err))
+		}
+	}
+	return nil, errs
+}
+
+// interceptorParam builds an interceptor param with a JSON encoded value.
+func interceptorParam(name string, value interface{}) tektonv1alpha1.InterceptorParams {
+	raw, _ := json.Marshal(value)
+	return tektonv1alpha1.InterceptorParams{Name: name, Value: apiextensionsv1.JSON{Raw: raw}}
+}
+
+// Helper function to convert Terraform interceptors into Tekton trigger interceptors
+func getTriggerInterceptors(tfInterceptors []interface{}) ([]*tektonv1alpha1.TriggerInterceptor, error) {
+	var interceptors []*tektonv1alpha1.TriggerInterceptor
+
+	for i, tfInterceptor := range tfInterceptors {
+		interceptorData := tfInterceptor.(map[string]interface{})
+		interceptor := &tektonv1alpha1.TriggerInterceptor{}
+		if v := interceptorData["name"].(string); v != "" {
+			interceptor.Name = &v
+		}
+
+		var kinds []string
+		for _, kind := range append(gitInterceptors, "cel", "webhook", "ref") {
+			if v := interceptorData[kind].([]interface{}); len(v) > 0 {
+				kinds = append(kinds, kind)
+			}
+		}
+		if len(kinds) != 1 {
+			return nil, fmt.Errorf("interceptors.%d: exactly one of github, gitlab, bitbucket, cel, webhook or ref must be set", i)
+		}
+		kind := kinds[0]
+		var blockData map[string]interface{}
+		if v := interceptorData[kind].([]interface{}); v[0] != nil {
+			blockData = v[0].(map[string]interface{})
+		}
+
+		switch kind {
+		case "github", "gitlab", "bitbucket":
+			interceptor.Ref = tektonv1alpha1.InterceptorRef{Name: kind, Kind: tektonv1alpha1.ClusterInterceptorKind}
+			if blockData == nil {
+				break
+			}
+			if v := blockData["secret_ref"].([]interface{}); len(v) > 0 && v[0] != nil {
+				secretData := v[0].(map[string]interface{})
+				interceptor.Params = append(interceptor.Params, interceptorParam("secretRef", map[string]string{
+					"secretName": secretData["secret_name"].(string),
+					"secretKey":  secretData["secret_key"].(string),
+				}))
+			}
+			if v := toStringSlice(blockData["event_types"].([]interface{})); len(v) > 0 {
+				interceptor.Params = append(interceptor.Params, interceptorParam("eventTypes", v))
+			}
+		case "cel":
+			interceptor.Ref = tektonv1alpha1.InterceptorRef{Name: kind, Kind: tektonv1alpha1.ClusterInterceptorKind}
+			if blockData == nil {
+				break
+			}
+			if v := blockData["filter"].(string); v != "" {
+				interceptor.Params = append(interceptor.Params, interceptorParam("filter", v))
+			}
+			var overlays []map[string]string
+			for _, tfOverlay := range blockData["overlays"].([]interface{}) {
+				overlayData := tfOverlay.(map[string]interface{})
+				overlays = append(overlays, map[string]string{
+					"key":        overlayData["key"].(string),
+					"expression": overlayData["expression"].(string),
+				})
+			}
+			if len(overlays) > 0 {
+				interceptor.Params = append(interceptor.Params, interceptorParam("overlays", overlays))
+			}
+		case "webhook":
+			webhook, err := getWebhookInterceptor(blockData)
+			if err != nil {
+				return nil, fmt.Errorf("interceptors.%d.webhook: %v", i, err)
+			}
+			interceptor.Webhook = webhook
+		case "ref":
+			interceptor.Ref = tektonv1alpha1.InterceptorRef{
+				Name: blockData["name"].(string),
+				Kind: tektonv1alpha1.InterceptorKind(blockData["kind"].(string)),
+			}
+			for name, value := range blockData["params"].(map[string]interface{}) {
+				interceptor.Params = append(interceptor.Params, tektonv1alpha1.InterceptorParams{
+					Name:  name,
+					Value: apiextensionsv1.JSON{Raw: []byte(value.(string))},
+				})
+			}
+		}
+
+		interceptors = append(interceptors, interceptor)
+	}
+
+	return interceptors, nil
+}
+
+func getWebhookInterceptor(webhookData map[string]interface{}) (*tektonv1alpha1.WebhookInterceptor, error) {
+	if webhookData == nil {
+		return nil, fmt.Errorf("one of url or service must be set")
+	}
+	webhook := &tektonv1alpha1.WebhookInterceptor{}
+
+	rawURL := webhookData["url"].(string)
+	tfService := webhookData["service"].([]interface{})
+	switch {
+	case rawURL != "" && len(tfService) > 0:
+		return nil, fmt.Errorf("url and service are mutually exclusive")
+	case rawURL != "":
+		url, err := apis.ParseURL(rawURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid url %q: %v", rawURL, err)
+		}
+		webhook.URL = url
+	case len(tfService) > 0 && tfService[0] != nil:
+		serviceData := tfService[0].(map[string]interface{})
+		webhook.ObjectRef = &corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Service",
+			Name:       serviceData["name"].(string),
+			Namespace:  serviceData["namespace"].(string),
+		}
+	default:
+		return nil, fmt.Errorf("one of url or service must be set")
+	}
+
+	for _, tfHeader := range webhookData["headers"].([]interface{}) {
+		headerData := tfHeader.(map[string]interface{})
+		values := toStringSlice(headerData["values"].([]interface{}))
+		header := tektonv1beta1.Param{Name: headerData["name"].(string)}
+		if len(values) == 1 {
+			header.Value = *tektonv1beta1.NewStructuredValues(values[0])
+		} else {
+			header.Value = tektonv1beta1.ParamValue{Type: tektonv1beta1.ParamTypeArray, ArrayVal: values}
+		}
+		webhook.Header = append(webhook.Header, header)
+	}
+
+	return webhook, nil
+}
+
+// Helper function to convert Terraform trigger bindings into Tekton trigger bindings
+func getTriggerSpecBindings(tfBindings []interface{}) ([]*tektonv1alpha1.TriggerSpecBinding, error) {
+	var bindings []*tektonv1alpha1.TriggerSpecBinding
+	for i, tfBinding := range tfBindings {
+		bindingData := tfBinding.(map[string]interface{})
+		ref := bindingData["ref"].(string)
+		name := bindingData["name"].(string)
+
+		switch {
+		case ref != "" && name != "":
+			return nil, fmt.Errorf("bindings.%d: ref and name are mutually exclusive", i)
+		case ref != "":
+			bindings = append(bindings, &tektonv1alpha1.TriggerSpecBinding{
+				Ref:  ref,
+				Kind: tektonv1alpha1.TriggerBindingKind(bindingData["kind"].(string)),
+			})
+		case name != "":
+			value := bindingData["value"].(string)
+			bindings = append(bindings, &tektonv1alpha1.TriggerSpecBinding{
+				Name:  name,
+				Value: &value,
+			})
+		default:
+			return nil, fmt.Errorf("bindings.%d: one of ref or name must be set", i)
+		}
+	}
+	return bindings, nil
+}
+
+// Helper function to convert a Terraform trigger template into a Tekton trigger template
+func getTriggerSpecTemplate(tfTemplate []interface{}) (*tektonv1alpha1.TriggerSpecTemplate, error) {
+	if len(tfTemplate) == 0 || tfTemplate[0] == nil {
+		return nil, nil
+	}
+	templateData := tfTemplate[0].(map[string]interface{})
+	ref := templateData["ref"].(string)
+	tfSpec := templateData["spec"].([]interface{})
+
+	switch {
+	case ref != "" && len(tfSpec) > 0:
+		return nil, fmt.Errorf("template: ref and spec are mutually exclusive")
+	case ref != "":
+		return &tektonv1alpha1.TriggerSpecTemplate{Ref: &ref}, nil
+	case len(tfSpec) > 0 && tfSpec[0] != nil:
+		specData := tfSpec[0].(map[string]interface{})
+		spec, err := getTriggerTemplateSpec(specData["params"].([]interface{}), specData["resourcetemplates"].([]interface{}))
+		if err != nil {
+			return nil, fmt.Errorf("template: %v", err)
+		}
+		return &tektonv1alpha1.TriggerSpecTemplate{Spec: spec}, nil
+	default:
+		return nil, fmt.Errorf("template: one of ref or spec must be set")
+	}
+}
+
+func flattenTriggerSpecBindings(bindings []*tektonv1alpha1.TriggerSpecBinding) []interface{} {
+	var tfBindings []interface{}
+	for _, binding := range bindings {
+		kind := string(binding.Kind)
+		if kind == "" {
+			kind = string(tektonv1alpha1.NamespacedTriggerBindingKind)
+		}
+		tfBinding := map[string]interface{}{
+			"ref":  binding.Ref,
+			"kind": kind,
+			"name": binding.Name,
+		}
+		if binding.Value != nil {
+			tfBinding["value"] = *binding.Value
+		}
+		tfBindings = append(tfBindings, tfBinding)
+	}
+	return tfBindings
+}
+
+func flattenTriggerSpecTemplate(template *tektonv1alpha1.TriggerSpecTemplate) []interface{} {
+	switch {
+	case template == nil:
+		return nil
+	case template.Ref != nil:
+		return []interface{}{map[string]interface{}{"ref": *template.Ref}}
+	case template.Spec != nil:
+		var resourceTemplates []interface{}
+		for _, resourceTemplate := range template.Spec.ResourceTemplates {
+			resourceTemplates = append(resourceTemplates, string(resourceTemplate.Raw))
+		}
+		return []interface{}{map[string]interface{}{
+			"spec": []interface{}{map[string]interface{}{
+				"params":            flattenTriggerTemplateParams(template.Spec.Params),
+				"resourcetemplates": resourceTemplates,
+			}},
+		}}
+	default:
+		return nil
+	}
+}
+
+// flattenTriggerInterceptors converts Tekton trigger interceptors into interceptors blocks. A
+// reference to a built-in interceptor is flattened into its github, gitlab, bitbucket or cel
+// block unless the prior block at the same index used ref, or its params do not fit the block.
+func flattenTriggerInterceptors(interceptors []*tektonv1alpha1.TriggerInterceptor, tfPrior []interface{}) []interface{} {
+	var tfInterceptors []interface{}
+	for i, interceptor := range interceptors {
+		tfInterceptor := map[string]interface{}{}
+		if interceptor.Name != nil {
+			tfInterceptor["name"] = *interceptor.Name
+		}
+
+		priorRef := false
+		if i < len(tfPrior) && tfPrior[i] != nil {
+			v, _ := tfPrior[i].(map[string]interface{})["ref"].([]interface{})
+			priorRef = len(v) > 0
+		}
+
+		switch {
+		case interceptor.Webhook != nil:
+			tfInterceptor["webhook"] = flattenWebhookInterceptor(interceptor.Webhook)
+		case priorRef || interceptor.Ref.Kind == tektonv1alpha1.NamespacedInterceptorKind || !flattenBuiltinInterceptor(interceptor, tfInterceptor):
+			tfInterceptor["ref"] = flattenInterceptorRef(interceptor)
+		}
+
+		tfInterceptors = append(tfInterceptors, tfInterceptor)
+	}
+	return tfInterceptors
+}
+
+func flattenInterceptorRef(interceptor *tektonv1alpha1.TriggerInterceptor) []interface{} {
+	kind := string(interceptor.Ref.Kind)
+	if kind == "" {
+		kind = string(tektonv1alpha1.ClusterInterceptorKind)
+	}
+	params := map[string]interface{}{}
+	for _, param := range interceptor.Params {
+		params[param.Name] = string(param.Value.Raw)
+	}
+	return []interface{}{map[string]interface{}{
+		"name":   interceptor.Ref.Name,
+		"kind":   kind,
+		"params": params,
+	}}
+}
+
+// flattenBuiltinInterceptor sets the github, gitlab, bitbucket or cel block of tfInterceptor from
+// a reference to the built-in interceptor, and reports whether its params fit the block.
+func flattenBuiltinInterceptor(interceptor *tektonv1alpha1.TriggerInterceptor, tfInterceptor map[string]interface{}) bool {
+	name := interceptor.Ref.Name
+	block := map[string]interface{}{}
+	for _, param := range interceptor.Params {
+		var err error
+		switch {
+		case name == "cel" && param.Name == "filter":
+			var filter string
+			err = json.Unmarshal(param.Value.Raw, &filter)
+			block["filter"] = filter
+		case name == "cel" && param.Name == "overlays":
+			var overlays []map[string]string
+			err = json.Unmarshal(param.Value.Raw, &overlays)
+			var tfOverlays []interface{}
+			for _, overlay := range overlays {
+				tfOverlays = append(tfOverlays, map[string]interface{}{
+					"key":        overlay["key"],
+					"expression": overlay["expression"],
+				})
+			}
+			block["overlays"] = tfOverlays
+		case name != "cel" && param.Name == "secretRef":
+			var secretRef map[string]string
+			err = json.Unmarshal(param.Value.Raw, &secretRef)
+			block["secret_ref"] = []interface{}{map[string]interface{}{
+				"secret_name": secretRef["secretName"],
+				"secret_key":  secretRef["secretKey"],
+			}}
+		case name != "cel" && param.Name == "eventTypes":
+			var eventTypes []string
+			err = json.Unmarshal(param.Value.Raw, &eventTypes)
+			block["event_types"] = eventTypes
+		default:
+			return false
+		}
+		if err != nil {
+			return false
+		}
+	}
+
+	for _, builtin := range append(gitInterceptors, "cel") {
+		if name == builtin {
+			tfInterceptor[name] = []interface{}{block}
+			return true
+		}
+	}
+	return false
+}
+
+func flattenWebhookInterceptor(webhook *tektonv1alpha1.WebhookInterceptor) []interface{} {
+	tfWebhook := map[string]interface{}{}
+	if webhook.URL != nil {
+		tfWebhook["url"] = webhook.URL.String()
+	}
+	if webhook.ObjectRef != nil {
+		tfWebhook["service"] = []interface{}{map[string]interface{}{
+			"name":      webhook.ObjectRef.Name,
+			"namespace": webhook.ObjectRef.Namespace,
+		}}
+	}
+	var headers []interface{}
+	for _, header := range webhook.Header {
+		values := header.Value.ArrayVal
+		if header.Value.Type != tektonv1beta1.ParamTypeArray {
+			values = []string{header.Value.StringVal}
+		}
+		headers = append(headers, map[string]interface{}{
+			"name":   header.Name,
+			"values": values,
+		})
+	}
+	tfWebhook["headers"] = headers
+	return []interface{}{tfWebhook}
+}
+
+// checkTriggerParamsBound returns an error if a param of a trigger's template has no default and
+// is not set by any of its bindings. It runs at plan time only. Templates and bindings referenced
+// by name are looked up in the cluster, and the check is skipped when one cannot be read: Tekton
+// resolves references when the trigger fires, so a missing one may still be created later.
+func checkTriggerParamsBound(clients providerClients, namespace string, bindings []*tektonv1alpha1.TriggerSpecBinding, template *tektonv1alpha1.TriggerSpecTemplate) error {
+	if template == nil {
+		return nil
+	}
+
+	var params []tektonv1alpha1.ParamSpec
+	templateName := "inline template"
+	switch {
+	case template.Spec != nil:
+		params = template.Spec.Params
+	case template.Ref != nil:
+		triggerTemplate, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerTemplates(namespace).Get(context.Background(), *template.Ref, metav1.GetOptions{})
+		if err != nil {
+			logSkippedParamsCheck(err, "TriggerTemplate", namespace, *template.Ref)
+			return nil
+		}
+		params = triggerTemplate.Spec.Params
+		templateName = fmt.Sprintf("TriggerTemplate %q", *template.Ref)
+	}
+
+	bound := map[string]bool{}
+	for _, binding := range bindings {
+		switch {
+		case binding.Name != "":
+			bound[binding.Name] = true
+		case binding.Kind == tektonv1alpha1.ClusterTriggerBindingKind:
+			clusterTriggerBinding, err := clients.TektonTriggersClient.TriggersV1alpha1().ClusterTriggerBindings().Get(context.Background(), binding.Ref, metav1.GetOptions{})
+			if err != nil {
+				logSkippedParamsCheck(err, "ClusterTriggerBinding", "", binding.Ref)
+				return nil
+			}
+			for _, param := range clusterTriggerBinding.Spec.Params {
+				bound[param.Name] = true
+			}
+		default:
+			triggerBinding, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerBindings(namespace).Get(context.Background(), binding.Ref, metav1.GetOptions{})
+			if err != nil {
+				logSkippedParamsCheck(err, "TriggerBinding", namespace, binding.Ref)
+				return nil
+			}
+			for _, param := range triggerBinding.Spec.Params {
+				bound[param.Name] = true
+			}
+		}
+	}
+
+	var missing []string
+	for _, param := range params {
+		if param.Default == nil && !bound[param.Name] {
+			missing = append(missing, param.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s params %q have no default and are not set by any binding", templateName, missing)
+	}
+	return nil
+}
+
+// logSkippedParamsCheck logs why the params check of a trigger was skipped: the referenced object
+// does not exist yet, or it could not be read, e.g. because the provider may not get it.
+func logSkippedParamsCheck(err error, kind, namespace, name string) {
+	if namespace != "" {
+		name = namespace + "/" + name
+	}
+	if errors.IsNotFound(err) {
+		log.Printf("[DEBUG] Skipping the trigger params check: %s %s does not exist yet", kind, name)
+		return
+	}
+	log.Printf("[WARN] Skipping the trigger params check: failed to get %s %s: %v", kind, name, err)
+}
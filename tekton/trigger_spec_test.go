@@ -0,0 +1,315 @@
+package tekton
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersfake "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestGetTriggerInterceptors(t *testing.T) {
+	tests := []struct {
+		name        string
+		interceptor map[string]interface{}
+		wantRef     tektonv1alpha1.InterceptorRef
+		wantParams  map[string]string
+		wantWebhook string
+		wantErr     string
+	}{
+		{
+			name: "github",
+			interceptor: map[string]interface{}{"github": []interface{}{map[string]interface{}{
+				"secret_ref":  []interface{}{map[string]interface{}{"secret_name": "webhook", "secret_key": "token"}},
+				"event_types": []interface{}{"push", "pull_request"},
+			}}},
+			wantRef: tektonv1alpha1.InterceptorRef{Name: "github", Kind: tektonv1alpha1.ClusterInterceptorKind},
+			wantParams: map[string]string{
+				"secretRef":  `{"secretKey":"token","secretName":"webhook"}`,
+				"eventTypes": `["push","pull_request"]`,
+			},
+		},
+		{
+			name: "gitlab",
+			interceptor: map[string]interface{}{"gitlab": []interface{}{map[string]interface{}{
+				"event_types": []interface{}{"Push Hook"},
+			}}},
+			wantRef:    tektonv1alpha1.InterceptorRef{Name: "gitlab", Kind: tektonv1alpha1.ClusterInterceptorKind},
+			wantParams: map[string]string{"eventTypes": `["Push Hook"]`},
+		},
+		{
+			name: "bitbucket",
+			interceptor: map[string]interface{}{"bitbucket": []interface{}{map[string]interface{}{
+				"secret_ref": []interface{}{map[string]interface{}{"secret_name": "webhook", "secret_key": "token"}},
+			}}},
+			wantRef:    tektonv1alpha1.InterceptorRef{Name: "bitbucket", Kind: tektonv1alpha1.ClusterInterceptorKind},
+			wantParams: map[string]string{"secretRef": `{"secretKey":"token","secretName":"webhook"}`},
+		},
+		{
+			name: "cel",
+			interceptor: map[string]interface{}{"cel": []interface{}{map[string]interface{}{
+				"filter": "body.ref == 'refs/heads/main'",
+				"overlays": []interface{}{
+					map[string]interface{}{"key": "short_sha", "expression": "body.after.truncate(7)"},
+				},
+			}}},
+			wantRef: tektonv1alpha1.InterceptorRef{Name: "cel", Kind: tektonv1alpha1.ClusterInterceptorKind},
+			wantParams: map[string]string{
+				"filter":   `"body.ref == 'refs/heads/main'"`,
+				"overlays": `[{"expression":"body.after.truncate(7)","key":"short_sha"}]`,
+			},
+		},
+		{
+			name: "webhook",
+			interceptor: map[string]interface{}{"webhook": []interface{}{map[string]interface{}{
+				"url": "http://filter.ci.svc:8080",
+			}}},
+			wantParams:  map[string]string{},
+			wantWebhook: "http://filter.ci.svc:8080",
+		},
+		{
+			name: "ref",
+			interceptor: map[string]interface{}{"ref": []interface{}{map[string]interface{}{
+				"name":   "slack",
+				"kind":   "NamespacedInterceptor",
+				"params": map[string]interface{}{"channel": `"builds"`, "mentions": `["@ci"]`},
+			}}},
+			wantRef: tektonv1alpha1.InterceptorRef{Name: "slack", Kind: tektonv1alpha1.NamespacedInterceptorKind},
+			wantParams: map[string]string{
+				"channel":  `"builds"`,
+				"mentions": `["@ci"]`,
+			},
+		},
+		{
+			name:        "none",
+			interceptor: map[string]interface{}{"name": "empty"},
+			wantErr:     "interceptors.0: exactly one of github, gitlab, bitbucket, cel, webhook or ref must be set",
+		},
+		{
+			name: "several",
+			interceptor: map[string]interface{}{
+				"github": []interface{}{map[string]interface{}{}},
+				"cel":    []interface{}{map[string]interface{}{"filter": "true"}},
+			},
+			wantErr: "interceptors.0: exactly one of github, gitlab, bitbucket, cel, webhook or ref must be set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{"interceptors": triggerInterceptorsSchema()}, map[string]interface{}{
+				"interceptors": []interface{}{tt.interceptor},
+			})
+
+			interceptors, err := getTriggerInterceptors(d.Get("interceptors").([]interface{}))
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Fatalf("getTriggerInterceptors() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("getTriggerInterceptors() error = %v", err)
+			}
+			if len(interceptors) != 1 {
+				t.Fatalf("getTriggerInterceptors() = %d interceptors, want 1", len(interceptors))
+			}
+			interceptor := interceptors[0]
+
+			if interceptor.Ref != tt.wantRef {
+				t.Errorf("Ref = %v, want %v", interceptor.Ref, tt.wantRef)
+			}
+			params := map[string]string{}
+			for _, param := range interceptor.Params {
+				params[param.Name] = string(param.Value.Raw)
+			}
+			if !reflect.DeepEqual(params, tt.wantParams) {
+				t.Errorf("Params = %v, want %v", params, tt.wantParams)
+			}
+			if tt.wantWebhook == "" {
+				if interceptor.Webhook != nil {
+					t.Errorf("Webhook = %v, want nil", interceptor.Webhook)
+				}
+			} else if interceptor.Webhook == nil || interceptor.Webhook.URL.String() != tt.wantWebhook {
+				t.Errorf("Webhook = %v, want URL %s", interceptor.Webhook, tt.wantWebhook)
+			}
+		})
+	}
+}
+
+func TestCheckTriggerParamsBound(t *testing.T) {
+	main := "main"
+	template := &tektonv1alpha1.TriggerSpecTemplate{
+		Spec: &tektonv1alpha1.TriggerTemplateSpec{
+			Params: []tektonv1alpha1.ParamSpec{
+				{Name: "revision"},
+				{Name: "branch", Default: &main},
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		bindings []*tektonv1alpha1.TriggerSpecBinding
+		template *tektonv1alpha1.TriggerSpecTemplate
+		wantErr  string
+	}{
+		{name: "no template", template: nil},
+		{
+			name:     "param set by a binding",
+			bindings: []*tektonv1alpha1.TriggerSpecBinding{{Name: "revision", Value: &main}},
+			template: template,
+		},
+		{
+			name:     "param with a default",
+			bindings: []*tektonv1alpha1.TriggerSpecBinding{{Name: "revision", Value: &main}, {Name: "branch", Value: &main}},
+			template: template,
+		},
+		{
+			name:     "unbound param",
+			bindings: []*tektonv1alpha1.TriggerSpecBinding{{Name: "other", Value: &main}},
+			template: template,
+			wantErr:  `inline template params ["revision"] have no default and are not set by any binding`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTriggerParamsBound(providerClients{}, "ci", tt.bindings, tt.template)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkTriggerParamsBound() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("checkTriggerParamsBound() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckTriggerParamsBoundReferences(t *testing.T) {
+	build := "build"
+	client := triggersfake.NewSimpleClientset(
+		&tektonv1alpha1.TriggerTemplate{
+			ObjectMeta: metav1.ObjectMeta{Name: "build", Namespace: "ci"},
+			Spec: tektonv1alpha1.TriggerTemplateSpec{
+				Params: []tektonv1alpha1.ParamSpec{{Name: "revision"}, {Name: "url"}},
+			},
+		},
+		&tektonv1alpha1.TriggerBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "push", Namespace: "ci"},
+			Spec:       tektonv1alpha1.TriggerBindingSpec{Params: []tektonv1alpha1.Param{{Name: "revision"}}},
+		},
+		&tektonv1alpha1.ClusterTriggerBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "repo"},
+			Spec:       tektonv1alpha1.TriggerBindingSpec{Params: []tektonv1alpha1.Param{{Name: "url"}}},
+		},
+	)
+	client.PrependReactor("get", "triggerbindings", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.(k8stesting.GetAction).GetName() != "secret" {
+			return false, nil, nil
+		}
+		return true, nil, errors.NewForbidden(k8sschema.GroupResource{Group: "triggers.tekton.dev", Resource: "triggerbindings"}, "secret", fmt.Errorf("denied"))
+	})
+	clients := providerClients{TektonTriggersClient: client}
+
+	missing := "missing"
+	tests := []struct {
+		name     string
+		bindings []*tektonv1alpha1.TriggerSpecBinding
+		template *tektonv1alpha1.TriggerSpecTemplate
+		wantErr  string
+	}{
+		{
+			name:     "bound by referenced bindings",
+			bindings: []*tektonv1alpha1.TriggerSpecBinding{{Ref: "push"}, {Ref: "repo", Kind: tektonv1alpha1.ClusterTriggerBindingKind}},
+			template: &tektonv1alpha1.TriggerSpecTemplate{Ref: &build},
+		},
+		{
+			name:     "unbound param of a referenced template",
+			bindings: []*tektonv1alpha1.TriggerSpecBinding{{Ref: "push"}},
+			template: &tektonv1alpha1.TriggerSpecTemplate{Ref: &build},
+			wantErr:  `TriggerTemplate "build" params ["url"] have no default and are not set by any binding`,
+		},
+		{
+			name:     "missing template skips the check",
+			template: &tektonv1alpha1.TriggerSpecTemplate{Ref: &missing},
+		},
+		{
+			name:     "missing binding skips the check",
+			bindings: []*tektonv1alpha1.TriggerSpecBinding{{Ref: "missing"}},
+			template: &tektonv1alpha1.TriggerSpecTemplate{Ref: &build},
+		},
+		{
+			name:     "forbidden binding skips the check",
+			bindings: []*tektonv1alpha1.TriggerSpecBinding{{Ref: "secret"}},
+			template: &tektonv1alpha1.TriggerSpecTemplate{Ref: &build},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTriggerParamsBound(clients, "ci", tt.bindings, tt.template)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkTriggerParamsBound() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("checkTriggerParamsBound() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResourceTektonTriggerRead(t *testing.T) {
+	d := testResourceData(t, resourceTektonTrigger(), map[string]interface{}{
+		"name":                 "push",
+		"namespace":            "ci",
+		"service_account_name": "triggers",
+		"bindings": []interface{}{
+			map[string]interface{}{"ref": "git-push"},
+			map[string]interface{}{"ref": "ci-defaults", "kind": "ClusterTriggerBinding"},
+			map[string]interface{}{"name": "revision", "value": "$(body.head_commit.id)"},
+		},
+		"template": []interface{}{map[string]interface{}{"ref": "build"}},
+		"interceptors": []interface{}{
+			map[string]interface{}{"github": []interface{}{map[string]interface{}{
+				"secret_ref":  []interface{}{map[string]interface{}{"secret_name": "webhook", "secret_key": "token"}},
+				"event_types": []interface{}{"push"},
+			}}},
+			map[string]interface{}{"cel": []interface{}{map[string]interface{}{
+				"filter": "body.ref == 'refs/heads/main'",
+			}}},
+			map[string]interface{}{"ref": []interface{}{map[string]interface{}{
+				"name":   "slack",
+				"kind":   "NamespacedInterceptor",
+				"params": map[string]interface{}{"channel": `"#ci"`},
+			}}},
+			map[string]interface{}{"webhook": []interface{}{map[string]interface{}{
+				"url": "http://filter.ci.svc:8080",
+			}}},
+		},
+	})
+	spec, err := getTriggerSpec(d)
+	if err != nil {
+		t.Fatalf("getTriggerSpec() error = %v", err)
+	}
+	d.SetId("push")
+
+	clients := providerClients{
+		TektonTriggersClient: triggersfake.NewSimpleClientset(&tektonv1alpha1.Trigger{
+			ObjectMeta: metav1.ObjectMeta{Name: "push", Namespace: "ci"},
+			Spec:       *spec,
+		}),
+		FieldManager: "terraform",
+	}
+	testReadKeepsConfig(t, d, resourceTektonTriggerRead, clients, "service_account_name", "bindings", "template", "interceptors")
+}
@@ -3,14 +3,26 @@ package tekton
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	tektonclient "github.com/tektoncd/pipeline/pkg/client/clientset/versioned"
+	tektonpipeline "github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	tektontriggers "github.com/tektoncd/triggers/pkg/apis/triggers"
 	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
-	triggersclient "github.com/tektoncd/triggers/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sschema "k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/yaml"
 )
 
+// triggerTemplateParamRef matches a $(tt.params.X) reference in a resource template.
+var triggerTemplateParamRef = regexp.MustCompile(`\$\(tt\.params\.([^)]+)\)`)
+
 // resourceTektonTriggerTemplate defines a Tekton TriggerTemplate.
 func resourceTektonTriggerTemplate() *schema.Resource {
 	return &schema.Resource{
@@ -19,83 +31,84 @@ func resourceTektonTriggerTemplate() *schema.Resource {
 		Update: resourceTektonTriggerTemplateUpdate,
 		Delete: resourceTektonTriggerTemplateDelete,
 
-		Schema: map[string]*schema.Schema{
+		CustomizeDiff: customdiff.Sequence(customizeTriggerTemplateDiff, customizeLabelsDiff),
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"namespace": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Default:  "default",
+				ForceNew: true,
 			},
-			"params": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"description": {
-							Type:     schema.TypeString,
-							Optional: true,
-						},
+		}, triggerTemplateSpecSchema(), metadataSchema()),
+	}
+}
+
+// triggerTemplateSpecSchema defines the fields of a TriggerTemplate spec, shared with inline template specs.
+func triggerTemplateSpecSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"params": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name": {
+						Type:     schema.TypeString,
+						Required: true,
 					},
-				},
-			},
-			"resourcetemplates": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"api_version": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"kind": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"metadata": {
-							Type:     schema.TypeMap,
-							Required: true,
-						},
-						"spec": {
-							Type:     schema.TypeMap,
-							Required: true,
-						},
+					"description": {
+						Type:     schema.TypeString,
+						Optional: true,
+					},
+					"default": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "The value used when no binding sets the param. An empty default is treated as no default.",
 					},
 				},
 			},
 		},
+		"resourcetemplates": {
+			Type:        schema.TypeList,
+			Required:    true,
+			Description: "Resources to create when the template is triggered, each as a JSON or YAML document (e.g. from yamlencode).",
+			Elem: &schema.Schema{
+				Type:             schema.TypeString,
+				ValidateFunc:     validateResourceTemplate,
+				DiffSuppressFunc: suppressEquivalentDocument,
+			},
+		},
 	}
 }
 
 func resourceTektonTriggerTemplateCreate(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Get("name").(string)
 	namespace := d.Get("namespace").(string)
 
-	params := getTriggerTemplateParams(d.Get("params").([]interface{}))
-	resourceTemplates := getResourceTemplates(d.Get("resourcetemplates").([]interface{}))
+	spec, err := getTriggerTemplateSpec(d.Get("params").([]interface{}), d.Get("resourcetemplates").([]interface{}))
+	if err != nil {
+		return err
+	}
 
 	triggerTemplate := &tektonv1alpha1.TriggerTemplate{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-		Spec: tektonv1alpha1.TriggerTemplateSpec{
-			Params:            params,
-			ResourceTemplates: resourceTemplates,
-		},
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       *spec,
+	}
+
+	if _, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerTemplates(namespace).Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "TriggerTemplate", namespace, name)
 	}
 
-	_, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerTemplates(namespace).Create(context.Background(), triggerTemplate, metav1.CreateOptions{})
+	patch, err := getApplyPatch(triggerTemplate)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().TriggerTemplates(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create Tekton TriggerTemplate: %v", err)
 	}
@@ -105,32 +118,63 @@ func resourceTektonTriggerTemplateCreate(d *schema.ResourceData, m interface{})
 }
 
 func resourceTektonTriggerTemplateRead(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
-	_, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerTemplates(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	triggerTemplate, err := clients.TektonTriggersClient.TriggersV1alpha1().TriggerTemplates(namespace).Get(context.Background(), name, metav1.GetOptions{})
 	if err != nil {
 		d.SetId("")
 		return nil
 	}
 
+	setObjectMeta(clients, d, triggerTemplate.ObjectMeta)
+
+	if managesField(clients, triggerTemplate.ObjectMeta, "spec", "params") {
+		d.Set("params", flattenTriggerTemplateParams(triggerTemplate.Spec.Params))
+	} else {
+		d.Set("params", nil)
+	}
+
+	var resourceTemplates []string
+	if managesField(clients, triggerTemplate.ObjectMeta, "spec", "resourcetemplates") {
+		for _, template := range triggerTemplate.Spec.ResourceTemplates {
+			resourceTemplates = append(resourceTemplates, string(template.Raw))
+		}
+	}
+	d.Set("resourcetemplates", resourceTemplates)
+
 	return nil
 }
 
 func resourceTektonTriggerTemplateUpdate(d *schema.ResourceData, m interface{}) error {
-	// TriggerTemplates are typically immutable after creation, so you may not allow updates
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	spec, err := getTriggerTemplateSpec(d.Get("params").([]interface{}), d.Get("resourcetemplates").([]interface{}))
+	if err != nil {
+		return err
+	}
+
+	triggerTemplate := &tektonv1alpha1.TriggerTemplate{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       *spec,
+	}
+
+	patch, err := getApplyPatch(triggerTemplate)
+	if err == nil {
+		_, err = clients.TektonTriggersClient.TriggersV1alpha1().TriggerTemplates(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton TriggerTemplate: %v", err)
+	}
+
 	return resourceTektonTriggerTemplateRead(d, m)
 }
 
 func resourceTektonTriggerTemplateDelete(d *schema.ResourceData, m interface{}) error {
-	clients := m.(struct {
-		TektonClient         *tektonclient.Clientset
-		TektonTriggersClient *triggersclient.Clientset
-	})
+	clients := m.(providerClients)
 	name := d.Id()
 	namespace := d.Get("namespace").(string)
 
@@ -143,6 +187,21 @@ func resourceTektonTriggerTemplateDelete(d *schema.ResourceData, m interface{})
 	return nil
 }
 
+// Helper function to build a TriggerTemplate spec from Terraform params and resource templates
+func getTriggerTemplateSpec(tfParams []interface{}, tfResourceTemplates []interface{}) (*tektonv1alpha1.TriggerTemplateSpec, error) {
+	resourceTemplates, err := getResourceTemplates(tfResourceTemplates)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTriggerTemplateParamRefs(tfParams, tfResourceTemplates); err != nil {
+		return nil, err
+	}
+	return &tektonv1alpha1.TriggerTemplateSpec{
+		Params:            getTriggerTemplateParams(tfParams),
+		ResourceTemplates: resourceTemplates,
+	}, nil
+}
+
 // Helper function to convert Terraform params into Tekton params
 func getTriggerTemplateParams(tfParams []interface{}) []tektonv1alpha1.ParamSpec {
 	var params []tektonv1alpha1.ParamSpec
@@ -154,23 +213,110 @@ func getTriggerTemplateParams(tfParams []interface{}) []tektonv1alpha1.ParamSpec
 		if v, ok := paramData["description"]; ok {
 			param.Description = v.(string)
 		}
+		if v, ok := paramData["default"].(string); ok && v != "" {
+			param.Default = &v
+		}
 		params = append(params, param)
 	}
 	return params
 }
 
+func flattenTriggerTemplateParams(params []tektonv1alpha1.ParamSpec) []interface{} {
+	var tfParams []interface{}
+	for _, param := range params {
+		tfParam := map[string]interface{}{
+			"name":        param.Name,
+			"description": param.Description,
+			"default":     "",
+		}
+		if param.Default != nil {
+			tfParam["default"] = *param.Default
+		}
+		tfParams = append(tfParams, tfParam)
+	}
+	return tfParams
+}
+
 // Helper function to convert resource templates for Tekton
-func getResourceTemplates(tfResourceTemplates []interface{}) []tektonv1alpha1.TriggerResourceTemplate {
+func getResourceTemplates(tfResourceTemplates []interface{}) ([]tektonv1alpha1.TriggerResourceTemplate, error) {
 	var templates []tektonv1alpha1.TriggerResourceTemplate
-	// for _, tfTemplate := range tfResourceTemplates {
-	// 	templateData := tfTemplate.(map[string]interface{})
-	// 	template := tektonv1alpha1.TriggerResourceTemplate{
-	// 		APIVersion: templateData["api_version"].(string),
-	// 		Kind:       templateData["kind"].(string),
-	// 		Metadata:   templateData["metadata"].(map[string]interface{}),
-	// 		Spec:       templateData["spec"].(map[string]interface{}),
-	// 	}
-	// 	templates = append(templates, template)
-	// }
-	return templates
+	for i, tfTemplate := range tfResourceTemplates {
+		raw, err := yaml.YAMLToJSON([]byte(tfTemplate.(string)))
+		if err != nil {
+			return nil, fmt.Errorf("resourcetemplates.%d is not valid JSON or YAML: %v", i, err)
+		}
+		templates = append(templates, tektonv1alpha1.TriggerResourceTemplate{
+			RawExtension: runtime.RawExtension{Raw: raw},
+		})
+	}
+	return templates, nil
+}
+
+// validateResourceTemplate checks that a resource template is a document with an apiVersion
+// and kind, and that tekton.dev and triggers.tekton.dev kinds are known to the Tekton APIs the
+// provider is built with.
+func validateResourceTemplate(v interface{}, k string) ([]string, []error) {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal([]byte(v.(string)), &typeMeta); err != nil {
+		return nil, []error{fmt.Errorf("%q must be a JSON or YAML document: %v", k, err)}
+	}
+	if typeMeta.APIVersion == "" || typeMeta.Kind == "" {
+		return nil, []error{fmt.Errorf("%q must set apiVersion and kind", k)}
+	}
+
+	gv, err := k8sschema.ParseGroupVersion(typeMeta.APIVersion)
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q has an invalid apiVersion %q: %v", k, typeMeta.APIVersion, err)}
+	}
+	if (gv.Group == tektonpipeline.GroupName || gv.Group == tektontriggers.GroupName) && !tektonScheme.Recognizes(gv.WithKind(typeMeta.Kind)) {
+		return nil, []error{fmt.Errorf("%q has unknown Tekton kind %s %s", k, typeMeta.APIVersion, typeMeta.Kind)}
+	}
+
+	return nil, nil
+}
+
+// suppressEquivalentDocument ignores differences between JSON or YAML documents
+// that decode to the same value, such as key ordering or formatting.
+func suppressEquivalentDocument(k, old, new string, d *schema.ResourceData) bool {
+	var oldValue, newValue interface{}
+	if err := yaml.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+	if err := yaml.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
+// customizeTriggerTemplateDiff checks at plan time that the resource templates only reference declared params.
+func customizeTriggerTemplateDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !rawPlanKnown(d, "params", "resourcetemplates") {
+		return nil
+	}
+	return checkTriggerTemplateParamRefs(d.Get("params").([]interface{}), d.Get("resourcetemplates").([]interface{}))
+}
+
+// checkTriggerTemplateParamRefs returns an error naming every $(tt.params.X) in the resource
+// templates that is not declared in params.
+func checkTriggerTemplateParamRefs(tfParams []interface{}, tfResourceTemplates []interface{}) error {
+	declared := map[string]bool{}
+	for _, tfParam := range tfParams {
+		declared[tfParam.(map[string]interface{})["name"].(string)] = true
+	}
+
+	var errs []string
+	for i, tfTemplate := range tfResourceTemplates {
+		seen := map[string]bool{}
+		for _, match := range triggerTemplateParamRef.FindAllStringSubmatch(tfTemplate.(string), -1) {
+			name := match[1]
+			if !declared[name] && !seen[name] {
+				errs = append(errs, fmt.Sprintf("resourcetemplates.%d references undeclared param %q", i, name))
+			}
+			seen[name] = true
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
 }
@@ -0,0 +1,177 @@
+package tekton
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	tektonv1alpha1 "github.com/tektoncd/triggers/pkg/apis/triggers/v1alpha1"
+	triggersfake "github.com/tektoncd/triggers/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestValidateResourceTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		wantErr  string
+	}{
+		{name: "pipeline run", template: "apiVersion: tekton.dev/v1beta1\nkind: PipelineRun"},
+		{name: "v1 task run", template: `{"apiVersion":"tekton.dev/v1","kind":"TaskRun"}`},
+		{name: "trigger binding", template: "apiVersion: triggers.tekton.dev/v1beta1\nkind: TriggerBinding"},
+		{name: "other group", template: "apiVersion: v1\nkind: ConfigMap"},
+		{name: "unknown Tekton kind", template: "apiVersion: tekton.dev/v1beta1\nkind: Bogus", wantErr: "unknown Tekton kind"},
+		{name: "unknown triggers kind", template: "apiVersion: triggers.tekton.dev/v1beta1\nkind: Bogus", wantErr: "unknown Tekton kind"},
+		{name: "missing kind", template: "apiVersion: tekton.dev/v1beta1", wantErr: "must set apiVersion and kind"},
+		{name: "invalid apiVersion", template: "apiVersion: a/b/c\nkind: PipelineRun", wantErr: "invalid apiVersion"},
+		{name: "not a document", template: "- a\n- b", wantErr: "must be a JSON or YAML document"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := validateResourceTemplate(tt.template, "resourcetemplates.0")
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("validateResourceTemplate() = %v, want no errors", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("validateResourceTemplate() = %v, want an error containing %q", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSuppressEquivalentDocument(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want bool
+	}{
+		{name: "YAML and JSON", old: "kind: PipelineRun\napiVersion: tekton.dev/v1", new: `{"apiVersion":"tekton.dev/v1","kind":"PipelineRun"}`, want: true},
+		{name: "key order", old: `{"a":1,"b":2}`, new: `{"b":2,"a":1}`, want: true},
+		{name: "different values", old: `{"a":1}`, new: `{"a":2}`, want: false},
+		{name: "invalid document", old: `{"a":1}`, new: `{"a":`, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := suppressEquivalentDocument("resourcetemplates.0", tt.old, tt.new, nil); got != tt.want {
+				t.Errorf("suppressEquivalentDocument() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckTriggerTemplateParamRefs(t *testing.T) {
+	params := []interface{}{
+		map[string]interface{}{"name": "revision"},
+	}
+
+	tests := []struct {
+		name      string
+		templates []interface{}
+		wantErr   string
+	}{
+		{name: "declared param", templates: []interface{}{"value: $(tt.params.revision)"}},
+		{name: "no param refs", templates: []interface{}{"kind: PipelineRun"}},
+		{
+			name:      "undeclared param reported once",
+			templates: []interface{}{"a: $(tt.params.branch)\nb: $(tt.params.branch)"},
+			wantErr:   `resourcetemplates.0 references undeclared param "branch"`,
+		},
+		{
+			name:      "undeclared params in several templates",
+			templates: []interface{}{"a: $(tt.params.revision)", "b: $(tt.params.branch)\nc: $(tt.params.url)"},
+			wantErr:   `resourcetemplates.1 references undeclared param "branch"; resourcetemplates.1 references undeclared param "url"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkTriggerTemplateParamRefs(params, tt.templates)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkTriggerTemplateParamRefs() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("checkTriggerTemplateParamRefs() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFlattenTriggerTemplateParams(t *testing.T) {
+	main := "main"
+	params := []tektonv1alpha1.ParamSpec{
+		{Name: "revision", Description: "The commit to build."},
+		{Name: "branch", Default: &main},
+	}
+	want := []interface{}{
+		map[string]interface{}{"name": "revision", "description": "The commit to build.", "default": ""},
+		map[string]interface{}{"name": "branch", "description": "", "default": "main"},
+	}
+
+	got := flattenTriggerTemplateParams(params)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("flattenTriggerTemplateParams() = %v, want %v", got, want)
+	}
+	if roundTrip := getTriggerTemplateParams(got); !reflect.DeepEqual(roundTrip, params) {
+		t.Errorf("getTriggerTemplateParams(flattenTriggerTemplateParams()) = %v, want %v", roundTrip, params)
+	}
+}
+
+func TestResourceTektonTriggerTemplateRead(t *testing.T) {
+	main := "main"
+	tests := []struct {
+		name       string
+		fields     string
+		wantParams []interface{}
+	}{
+		{
+			name:   "params owned",
+			fields: `{"f:spec":{"f:params":{},"f:resourcetemplates":{}}}`,
+			wantParams: []interface{}{
+				map[string]interface{}{"name": "branch", "description": "", "default": "main"},
+			},
+		},
+		{
+			name:       "params not owned",
+			fields:     `{"f:spec":{"f:resourcetemplates":{}}}`,
+			wantParams: []interface{}{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := triggersfake.NewSimpleClientset(&tektonv1alpha1.TriggerTemplate{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "build",
+					Namespace: "ci",
+					ManagedFields: []metav1.ManagedFieldsEntry{{
+						Manager:   "terraform",
+						Operation: metav1.ManagedFieldsOperationApply,
+						FieldsV1:  &metav1.FieldsV1{Raw: []byte(tt.fields)},
+					}},
+				},
+				Spec: tektonv1alpha1.TriggerTemplateSpec{
+					Params: []tektonv1alpha1.ParamSpec{{Name: "branch", Default: &main}},
+				},
+			})
+			d := schema.TestResourceDataRaw(t, resourceTektonTriggerTemplate().Schema, map[string]interface{}{
+				"name":              "build",
+				"namespace":         "ci",
+				"resourcetemplates": []interface{}{"apiVersion: tekton.dev/v1\nkind: PipelineRun"},
+			})
+			d.SetId("build")
+
+			if err := resourceTektonTriggerTemplateRead(d, providerClients{TektonTriggersClient: client, FieldManager: "terraform"}); err != nil {
+				t.Fatalf("resourceTektonTriggerTemplateRead() error = %v", err)
+			}
+			if got := d.Get("params").([]interface{}); !reflect.DeepEqual(got, tt.wantParams) {
+				t.Errorf("params = %v, want %v", got, tt.wantParams)
+			}
+		})
+	}
+}
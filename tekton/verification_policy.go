@@ -0,0 +1,318 @@
+package tekton
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	tektonv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// resourceTektonVerificationPolicy defines a Tekton VerificationPolicy, which lists the keys
+// trusted to sign the Tasks and Pipelines matching its resource patterns.
+func resourceTektonVerificationPolicy() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceTektonVerificationPolicyCreate,
+		Read:   resourceTektonVerificationPolicyRead,
+		Update: resourceTektonVerificationPolicyUpdate,
+		Delete: resourceTektonVerificationPolicyDelete,
+
+		CustomizeDiff: customizeLabelsDiff,
+
+		Schema: mergeSchemas(map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"namespace": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "default",
+				ForceNew: true,
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Regular expressions matched against the source of remote Tasks and Pipelines, e.g. a git URL or bundle reference.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsValidRegExp,
+				},
+			},
+			"authorities": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"key": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Description: "The public key, set with exactly one of data, secret_ref or kms.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"data": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The PEM encoded public key.",
+									},
+									"secret_ref": {
+										Type:     schema.TypeList,
+										Optional: true,
+										MaxItems: 1,
+										Elem: &schema.Resource{
+											Schema: map[string]*schema.Schema{
+												"name": {
+													Type:     schema.TypeString,
+													Required: true,
+												},
+												"namespace": {
+													Type:        schema.TypeString,
+													Optional:    true,
+													Description: "The namespace of the Secret. Defaults to the policy's namespace.",
+												},
+											},
+										},
+									},
+									"kms": {
+										Type:        schema.TypeString,
+										Optional:    true,
+										Description: "The URI of a KMS key, e.g. gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k.",
+									},
+									"hash_algorithm": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "sha256",
+										ValidateFunc: validation.StringInSlice([]string{"sha224", "sha256", "sha384", "sha512"}, false),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(tektonv1alpha1.ModeEnforce),
+				ValidateFunc: validation.StringInSlice([]string{string(tektonv1alpha1.ModeEnforce), string(tektonv1alpha1.ModeWarn)}, false),
+				Description:  "Whether a failed verification fails the run (enforce) or only logs a warning (warn).",
+			},
+		}, metadataSchema()),
+	}
+}
+
+// resourceTektonVerificationPolicyCreate creates a Tekton VerificationPolicy.
+func resourceTektonVerificationPolicyCreate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Get("name").(string)
+	namespace := d.Get("namespace").(string)
+
+	spec, err := getVerificationPolicySpec(d, namespace)
+	if err != nil {
+		return err
+	}
+
+	verificationPolicy := &tektonv1alpha1.VerificationPolicy{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       *spec,
+	}
+
+	if _, err := clients.TektonClient.TektonV1alpha1().VerificationPolicies(namespace).Get(context.Background(), name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		return existingObjectError(err, "VerificationPolicy", namespace, name)
+	}
+
+	patch, err := getApplyPatch(verificationPolicy)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1alpha1().VerificationPolicies(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create Tekton VerificationPolicy: %v", err)
+	}
+
+	d.SetId(name)
+	return resourceTektonVerificationPolicyRead(d, m)
+}
+
+func resourceTektonVerificationPolicyRead(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	verificationPolicy, err := clients.TektonClient.TektonV1alpha1().VerificationPolicies(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		d.SetId("")
+		return nil
+	}
+
+	setObjectMeta(clients, d, verificationPolicy.ObjectMeta)
+
+	var resources []string
+	if managesField(clients, verificationPolicy.ObjectMeta, "spec", "resources") {
+		for _, resource := range verificationPolicy.Spec.Resources {
+			resources = append(resources, resource.Pattern)
+		}
+	}
+	d.Set("resources", resources)
+
+	authorities := flattenVerificationPolicyAuthorities(verificationPolicy.Spec.Authorities, namespace, d.Get("authorities").([]interface{}))
+	setManagedField(clients, d, verificationPolicy.ObjectMeta, "authorities", authorities, "spec", "authorities")
+
+	if managesField(clients, verificationPolicy.ObjectMeta, "spec", "mode") {
+		d.Set("mode", string(verificationPolicy.Spec.Mode))
+	} else {
+		d.Set("mode", "")
+	}
+
+	return nil
+}
+
+func resourceTektonVerificationPolicyUpdate(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	spec, err := getVerificationPolicySpec(d, namespace)
+	if err != nil {
+		return err
+	}
+
+	verificationPolicy := &tektonv1alpha1.VerificationPolicy{
+		ObjectMeta: getObjectMeta(clients.Metadata, d, name, namespace),
+		Spec:       *spec,
+	}
+
+	patch, err := getApplyPatch(verificationPolicy)
+	if err == nil {
+		_, err = clients.TektonClient.TektonV1alpha1().VerificationPolicies(namespace).Patch(context.Background(), name, types.ApplyPatchType, patch, getApplyOptions(clients))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to update Tekton VerificationPolicy: %v", err)
+	}
+
+	return resourceTektonVerificationPolicyRead(d, m)
+}
+
+func resourceTektonVerificationPolicyDelete(d *schema.ResourceData, m interface{}) error {
+	clients := m.(providerClients)
+	name := d.Id()
+	namespace := d.Get("namespace").(string)
+
+	err := clients.TektonClient.TektonV1alpha1().VerificationPolicies(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to delete Tekton VerificationPolicy: %v", err)
+	}
+
+	d.SetId("")
+	return nil
+}
+
+// Helper function to convert the Terraform verification policy configuration into a Tekton VerificationPolicy spec
+func getVerificationPolicySpec(d *schema.ResourceData, namespace string) (*tektonv1alpha1.VerificationPolicySpec, error) {
+	spec := &tektonv1alpha1.VerificationPolicySpec{
+		Mode: tektonv1alpha1.ModeType(d.Get("mode").(string)),
+	}
+
+	for _, pattern := range toStringSlice(d.Get("resources").([]interface{})) {
+		spec.Resources = append(spec.Resources, tektonv1alpha1.ResourcePattern{Pattern: pattern})
+	}
+
+	for i, tfAuthority := range d.Get("authorities").([]interface{}) {
+		authorityData := tfAuthority.(map[string]interface{})
+		key, err := getVerificationPolicyKey(authorityData["key"].([]interface{}), namespace)
+		if err != nil {
+			return nil, fmt.Errorf("authorities.%d.key: %v", i, err)
+		}
+		spec.Authorities = append(spec.Authorities, tektonv1alpha1.Authority{
+			Name: authorityData["name"].(string),
+			Key:  key,
+		})
+	}
+
+	return spec, nil
+}
+
+// Helper function to convert a Terraform key block into a Tekton KeyRef
+func getVerificationPolicyKey(tfKey []interface{}, namespace string) (*tektonv1alpha1.KeyRef, error) {
+	if len(tfKey) == 0 || tfKey[0] == nil {
+		return nil, fmt.Errorf("exactly one of data, secret_ref or kms must be set")
+	}
+	keyData := tfKey[0].(map[string]interface{})
+
+	key := &tektonv1alpha1.KeyRef{
+		Data:          keyData["data"].(string),
+		KMS:           keyData["kms"].(string),
+		HashAlgorithm: tektonv1alpha1.HashAlgorithm(keyData["hash_algorithm"].(string)),
+	}
+	sources := 0
+	if key.Data != "" {
+		sources++
+	}
+	if key.KMS != "" {
+		sources++
+	}
+	if v := keyData["secret_ref"].([]interface{}); len(v) > 0 && v[0] != nil {
+		refData := v[0].(map[string]interface{})
+		key.SecretRef = &corev1.SecretReference{
+			Name:      refData["name"].(string),
+			Namespace: refData["namespace"].(string),
+		}
+		if key.SecretRef.Namespace == "" {
+			key.SecretRef.Namespace = namespace
+		}
+		sources++
+	}
+	if sources != 1 {
+		return nil, fmt.Errorf("exactly one of data, secret_ref or kms must be set")
+	}
+
+	return key, nil
+}
+
+// flattenVerificationPolicyAuthorities converts Tekton authorities into authorities blocks. A
+// secret namespace equal to the policy's namespace is left out if the prior block left it out.
+func flattenVerificationPolicyAuthorities(authorities []tektonv1alpha1.Authority, namespace string, tfPrior []interface{}) []interface{} {
+	var tfAuthorities []interface{}
+	for i, authority := range authorities {
+		var tfKey []interface{}
+		if key := authority.Key; key != nil {
+			keyData := map[string]interface{}{
+				"data":           key.Data,
+				"kms":            key.KMS,
+				"hash_algorithm": string(key.HashAlgorithm),
+			}
+			if key.SecretRef != nil {
+				var priorKey []interface{}
+				if i < len(tfPrior) && tfPrior[i] != nil {
+					priorKey, _ = tfPrior[i].(map[string]interface{})["key"].([]interface{})
+				}
+				secretNamespace := key.SecretRef.Namespace
+				if secretNamespace == namespace && getPriorString(priorKey, "secret_ref", "namespace") == "" {
+					secretNamespace = ""
+				}
+				keyData["secret_ref"] = []interface{}{map[string]interface{}{
+					"name":      key.SecretRef.Name,
+					"namespace": secretNamespace,
+				}}
+			}
+			tfKey = []interface{}{keyData}
+		}
+		tfAuthorities = append(tfAuthorities, map[string]interface{}{
+			"name": authority.Name,
+			"key":  tfKey,
+		})
+	}
+	return tfAuthorities
+}
@@ -0,0 +1,49 @@
+package tekton
+
+import (
+	"testing"
+
+	tektonv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
+	tektonfake "github.com/tektoncd/pipeline/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResourceTektonVerificationPolicyRead(t *testing.T) {
+	d := testResourceData(t, resourceTektonVerificationPolicy(), map[string]interface{}{
+		"name":      "signed",
+		"namespace": "ci",
+		"resources": []interface{}{"https://github.com/example/.*"},
+		"authorities": []interface{}{
+			map[string]interface{}{
+				"name": "release",
+				"key":  []interface{}{map[string]interface{}{"secret_ref": []interface{}{map[string]interface{}{"name": "cosign"}}}},
+			},
+			map[string]interface{}{
+				"name": "shared",
+				"key": []interface{}{map[string]interface{}{
+					"secret_ref":     []interface{}{map[string]interface{}{"name": "cosign", "namespace": "keys"}},
+					"hash_algorithm": "sha512",
+				}},
+			},
+			map[string]interface{}{
+				"name": "kms",
+				"key":  []interface{}{map[string]interface{}{"kms": "gcpkms://projects/p/locations/l/keyRings/r/cryptoKeys/k"}},
+			},
+		},
+		"mode": "warn",
+	})
+	spec, err := getVerificationPolicySpec(d, "ci")
+	if err != nil {
+		t.Fatalf("getVerificationPolicySpec() error = %v", err)
+	}
+	d.SetId("signed")
+
+	clients := providerClients{
+		TektonClient: tektonfake.NewSimpleClientset(&tektonv1alpha1.VerificationPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "signed", Namespace: "ci"},
+			Spec:       *spec,
+		}),
+		FieldManager: "terraform",
+	}
+	testReadKeepsConfig(t, d, resourceTektonVerificationPolicyRead, clients, "resources", "authorities", "mode")
+}